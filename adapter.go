@@ -0,0 +1,80 @@
+package goviteparser
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+type (
+	ManifestAdapter interface {
+		Adapt(content []byte) (Manifest, error)
+	}
+
+	EncoreAdapter struct {
+		PublicPath string
+	}
+
+	encoreEntrypoints struct {
+		Entrypoints map[string]struct {
+			JS  []string `json:"js"`
+			CSS []string `json:"css"`
+		} `json:"entrypoints"`
+	}
+)
+
+func (adapter EncoreAdapter) Adapt(content []byte) (Manifest, error) {
+	var entrypoints encoreEntrypoints
+	if err := json.Unmarshal(content, &entrypoints); err != nil {
+		return nil, err
+	}
+
+	manifest := make(Manifest)
+	if entrypoints.Entrypoints == nil {
+		var files map[string]string
+		if err := json.Unmarshal(content, &files); err != nil {
+			return nil, err
+		}
+
+		for key, file := range files {
+			manifest[key] = EntryInfo{File: adapter.trim(file)}
+		}
+
+		return manifest, nil
+	}
+
+	for name, entrypoint := range entrypoints.Entrypoints {
+		entryInfo := EntryInfo{IsEntry: true, classic: true}
+		for _, cssPath := range entrypoint.CSS {
+			entryInfo.CSS = append(entryInfo.CSS, adapter.trim(cssPath))
+		}
+
+		if len(entrypoint.JS) == 0 && len(entryInfo.CSS) > 0 {
+			entryInfo.File = entryInfo.CSS[len(entryInfo.CSS)-1]
+			entryInfo.CSS = entryInfo.CSS[:len(entryInfo.CSS)-1]
+			manifest[name] = entryInfo
+			continue
+		}
+
+		for i, jsPath := range entrypoint.JS {
+			file := adapter.trim(jsPath)
+			if i == len(entrypoint.JS)-1 {
+				entryInfo.File = file
+				continue
+			}
+
+			entryInfo.scripts = append(entryInfo.scripts, file)
+		}
+
+		manifest[name] = entryInfo
+	}
+
+	return manifest, nil
+}
+
+func (adapter EncoreAdapter) trim(file string) string {
+	if adapter.PublicPath == "" {
+		return file
+	}
+
+	return strings.TrimPrefix(file, adapter.PublicPath)
+}
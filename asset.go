@@ -0,0 +1,109 @@
+package goviteparser
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+)
+
+const assetHashLength = 8
+
+var (
+	ErrAssetNotFound  = errors.New("asset not found in manifest")
+	ErrAmbiguousAsset = errors.New("asset name matches several hashed files")
+)
+
+func (vite *ViteManifestInfo) Asset(asset string) (string, error) {
+	asset = vite.entryKey(vite.Manifest, asset)
+	if vite.IsDev() {
+		return vite.devURL(asset)
+	}
+
+	file, ok := vite.assetFile(asset)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrAssetNotFound, asset)
+	}
+
+	options := vite.config.urlOptions()
+	return options.assetURL(asset, vite.config.OutDir, file), nil
+}
+
+func (vite *ViteManifestInfo) Exists(asset string) bool {
+	asset = vite.entryKey(vite.Manifest, asset)
+	if vite.IsDev() {
+		return vite.sourceExists(asset)
+	}
+
+	_, ok := vite.assetFile(asset)
+	return ok
+}
+
+func (vite *ViteManifestInfo) TryAsset(asset string) (string, bool) {
+	if !vite.Exists(asset) {
+		return "", false
+	}
+
+	url, err := vite.Asset(asset)
+	return url, err == nil
+}
+
+func (vite *ViteManifestInfo) assetFile(asset string) (string, bool) {
+	asset = vite.entryKey(vite.Manifest, asset)
+	if entryInfo, ok := vite.Manifest[asset]; ok {
+		return entryInfo.File, true
+	}
+
+	file, ok := vite.assets[asset]
+	return file, ok
+}
+
+func indexAssets(manifest Manifest) (map[string]string, []string) {
+	keys := make([]string, 0, len(manifest))
+	for key := range manifest {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	assets := make(map[string]string)
+	aliases := make(map[string]string)
+	ambiguous := make(map[string]bool)
+	for _, key := range keys {
+		for _, file := range manifest[key].Assets {
+			assets[file] = file
+
+			name := unhashedAssetName(path.Base(file))
+			if alias, ok := aliases[name]; ok && alias != file {
+				ambiguous[name] = true
+				continue
+			}
+
+			aliases[name] = file
+		}
+	}
+
+	names := []string{}
+	for name, file := range aliases {
+		if ambiguous[name] {
+			names = append(names, name)
+			continue
+		}
+
+		if _, ok := assets[name]; !ok {
+			assets[name] = file
+		}
+	}
+	sort.Strings(names)
+
+	return assets, names
+}
+
+func unhashedAssetName(name string) string {
+	extension := path.Ext(name)
+	stem := name[:len(name)-len(extension)]
+	if len(stem) <= assetHashLength+1 || stem[len(stem)-assetHashLength-1] != '-' {
+		return name
+	}
+
+	return stem[:len(stem)-assetHashLength-1] + extension
+}
@@ -0,0 +1,149 @@
+package goviteparser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+)
+
+var benchmarkSizes = []struct {
+	chunks int
+	depth  int
+}{
+	{chunks: 100, depth: 3},
+	{chunks: 1000, depth: 5},
+	{chunks: 10000, depth: 8},
+}
+
+func generateManifest(chunks int, depth int) Manifest {
+	if depth < 1 {
+		depth = 1
+	}
+
+	manifest := make(Manifest, chunks)
+	perLevel := (chunks + depth - 1) / depth
+	key := func(level int, index int) string {
+		return fmt.Sprintf("src/level%d/chunk%d.ts", level, index)
+	}
+
+	for i := 0; i < chunks; i++ {
+		level, index := i/perLevel, i%perLevel
+		entryInfo := EntryInfo{
+			File:    fmt.Sprintf("assets/chunk%d-%08x.js", i, i),
+			Src:     key(level, index),
+			IsEntry: level == 0,
+		}
+
+		if level+1 < depth {
+			for _, next := range []int{index, (index + 1) % perLevel} {
+				if (level+1)*perLevel+next < chunks {
+					entryInfo.Imports = append(entryInfo.Imports, key(level+1, next))
+				}
+			}
+
+			if next := (index + 2) % perLevel; (level+1)*perLevel+next < chunks {
+				entryInfo.DynamicImports = append(entryInfo.DynamicImports, key(level+1, next))
+			}
+		}
+
+		if index%4 == 0 {
+			entryInfo.CSS = []string{fmt.Sprintf("assets/chunk%d-%08x.css", i, i)}
+		}
+
+		manifest[key(level, index)] = entryInfo
+	}
+
+	return manifest
+}
+
+func loadBenchmarkManifest(b *testing.B, chunks int, depth int) (ViteManifestInfo, []string) {
+	b.Helper()
+
+	content, err := json.Marshal(generateManifest(chunks, depth))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	vite, err := Load(Config{Mode: ModeProduction, OutDir: "build", Loader: StaticLoader(content)})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	entrypoints := []string{}
+	for key, entryInfo := range vite.Manifest {
+		if entryInfo.IsEntry {
+			entrypoints = append(entrypoints, key)
+		}
+	}
+	sort.Strings(entrypoints)
+
+	return vite, entrypoints[:min(len(entrypoints), 5)]
+}
+
+func BenchmarkInvoke(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("chunks=%d/depth=%d", size.chunks, size.depth), func(b *testing.B) {
+			vite, entrypoints := loadBenchmarkManifest(b, size.chunks, size.depth)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := vite.InvokeCtx(context.Background(), entrypoints); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPreloadedAssets(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("chunks=%d/depth=%d", size.chunks, size.depth), func(b *testing.B) {
+			vite, entrypoints := loadBenchmarkManifest(b, size.chunks, size.depth)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := vite.PreloadedAssets(context.Background(), entrypoints); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDynamicImports(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("chunks=%d/depth=%d", size.chunks, size.depth), func(b *testing.B) {
+			vite, entrypoints := loadBenchmarkManifest(b, size.chunks, size.depth)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, entry := range entrypoints {
+					if _, err := vite.DynamicImports(entry); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkLoad(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("chunks=%d/depth=%d", size.chunks, size.depth), func(b *testing.B) {
+			content, err := json.Marshal(generateManifest(size.chunks, size.depth))
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := Load(Config{Mode: ModeProduction, OutDir: "build", Loader: StaticLoader(content)}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
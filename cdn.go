@@ -0,0 +1,64 @@
+package goviteparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"strings"
+)
+
+const failoverScript = `(function(bases){addEventListener("error",function(event){var target=event.target,attribute=target.tagName==="LINK"?"href":"src",source=target.getAttribute&&target.getAttribute(attribute),attempt=+(target.getAttribute&&target.getAttribute("data-cdn-failover"))||0;if(!source||attempt>=bases.length-1)return;for(var i=0;i<bases.length;i++){if(source.indexOf(bases[i])!==0)continue;var element=document.createElement(target.tagName);for(var j=0;j<target.attributes.length;j++)element.setAttribute(target.attributes[j].name,target.attributes[j].value);element.setAttribute(attribute,bases[(i+1)%%bases.length]+source.slice(bases[i].length));element.setAttribute("data-cdn-failover",attempt+1);target.parentNode.replaceChild(element,target);return}},true)})(%s);`
+
+func ShardedResolver(bases ...string) AssetPathResolver {
+	return func(rawURL string) string {
+		if len(bases) == 0 {
+			return rawURL
+		}
+
+		assetPath := rawURL
+		if assetURL, err := url.Parse(rawURL); err == nil && assetURL.Host != "" {
+			assetPath = assetURL.RequestURI()
+		}
+
+		hash := fnv.New32a()
+		hash.Write([]byte(assetPath))
+		base := bases[hash.Sum32()%uint32(len(bases))]
+
+		return strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(assetPath, "/")
+	}
+}
+
+func CDNFailoverScript(bases []string, nonce string) string {
+	return fmt.Sprintf("<script%s>%s</script>", nonceAttribute(nonce), cdnFailoverSource(bases))
+}
+
+func cdnFailoverSource(bases []string) string {
+	trimmed := make([]string, 0, len(bases))
+	for _, base := range bases {
+		trimmed = append(trimmed, strings.TrimSuffix(base, "/")+"/")
+	}
+
+	encoded, _ := json.Marshal(trimmed)
+	return fmt.Sprintf(failoverScript, encoded)
+}
+
+func (resolver AssetPathResolver) WithQueryParams(params map[string]string) AssetPathResolver {
+	return func(rawURL string) string {
+		if assetURL, err := url.Parse(rawURL); err == nil {
+			query := assetURL.Query()
+			for name, value := range params {
+				query.Set(name, value)
+			}
+
+			assetURL.RawQuery = query.Encode()
+			rawURL = assetURL.String()
+		}
+
+		if resolver != nil {
+			return resolver(rawURL)
+		}
+
+		return rawURL
+	}
+}
@@ -0,0 +1,54 @@
+package goviteparser
+
+import (
+	"io/fs"
+	"os"
+	"time"
+)
+
+type (
+	Clock interface {
+		Now() time.Time
+	}
+
+	FileSystem interface {
+		Stat(name string) (fs.FileInfo, error)
+		ReadFile(name string) ([]byte, error)
+	}
+
+	SystemClock struct{}
+
+	OSFileSystem struct{}
+)
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (OSFileSystem) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (OSFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (config Config) fileSystem() FileSystem {
+	return fileSystemOrDefault(config.FileSystem)
+}
+
+func fileSystemOrDefault(fileSystem FileSystem) FileSystem {
+	if fileSystem == nil {
+		return OSFileSystem{}
+	}
+
+	return fileSystem
+}
+
+func clockOrDefault(clock Clock) Clock {
+	if clock == nil {
+		return SystemClock{}
+	}
+
+	return clock
+}
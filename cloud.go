@@ -0,0 +1,238 @@
+package goviteparser
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	ObjectStoreConfig struct {
+		Bucket          string
+		Region          string
+		AccessKeyID     string
+		SecretAccessKey string
+		SessionToken    string
+		Endpoint        string
+		Clock           Clock
+	}
+
+	CloudFrontConfig struct {
+		BaseURL    string
+		KeyPairID  string
+		PrivateKey *rsa.PrivateKey
+		Clock      Clock
+	}
+
+	signatureScheme struct {
+		algorithm   string
+		queryPrefix string
+		keyPrefix   string
+		service     string
+		terminator  string
+	}
+
+	cachedSignature struct {
+		url     string
+		renewAt time.Time
+	}
+)
+
+var (
+	s3Scheme = signatureScheme{
+		algorithm:   "AWS4-HMAC-SHA256",
+		queryPrefix: "X-Amz-",
+		keyPrefix:   "AWS4",
+		service:     "s3",
+		terminator:  "aws4_request",
+	}
+	gcsScheme = signatureScheme{
+		algorithm:   "GOOG4-HMAC-SHA256",
+		queryPrefix: "X-Goog-",
+		keyPrefix:   "GOOG4",
+		service:     "storage",
+		terminator:  "goog4_request",
+	}
+)
+
+func S3PresignedResolver(config ObjectStoreConfig, ttl time.Duration) AssetPathResolver {
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", config.Bucket, config.Region)
+	}
+
+	return cachedResolver(config.Clock, ttl, func(rawURL string, now time.Time) string {
+		return presignObjectURL(s3Scheme, config, endpoint, rawURL, now, ttl)
+	})
+}
+
+func GCSSignedResolver(config ObjectStoreConfig, ttl time.Duration) AssetPathResolver {
+	if config.Region == "" {
+		config.Region = "auto"
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://storage.googleapis.com/" + config.Bucket
+	}
+
+	return cachedResolver(config.Clock, ttl, func(rawURL string, now time.Time) string {
+		return presignObjectURL(gcsScheme, config, endpoint, rawURL, now, ttl)
+	})
+}
+
+func CloudFrontSignedResolver(config CloudFrontConfig, ttl time.Duration) AssetPathResolver {
+	return cachedResolver(config.Clock, ttl, func(rawURL string, now time.Time) string {
+		resource := strings.TrimSuffix(config.BaseURL, "/") + "/" + uriEscape(strings.TrimPrefix(objectKey(rawURL), "/"), false)
+		separator := "?"
+		if query := objectQuery(rawURL); len(query) > 0 {
+			resource += "?" + query.Encode()
+			separator = "&"
+		}
+		expires := strconv.FormatInt(now.Add(ttl).Unix(), 10)
+		policy := fmt.Sprintf(`{"Statement":[{"Resource":"%s","Condition":{"DateLessThan":{"AWS:EpochTime":%s}}}]}`, resource, expires)
+
+		digest := sha1.Sum([]byte(policy))
+		signature, err := rsa.SignPKCS1v15(nil, config.PrivateKey, crypto.SHA1, digest[:])
+		if err != nil {
+			return rawURL
+		}
+
+		encoded := strings.NewReplacer("+", "-", "=", "_", "/", "~").Replace(base64.StdEncoding.EncodeToString(signature))
+		return fmt.Sprintf("%s%sExpires=%s&Signature=%s&Key-Pair-Id=%s", resource, separator, expires, encoded, config.KeyPairID)
+	})
+}
+
+func cachedResolver(clock Clock, ttl time.Duration, sign func(rawURL string, now time.Time) string) AssetPathResolver {
+	clock = clockOrDefault(clock)
+
+	var mutex sync.Mutex
+	cache := make(map[string]cachedSignature)
+
+	return func(rawURL string) string {
+		now := clock.Now()
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if cached, ok := cache[rawURL]; ok && now.Before(cached.renewAt) {
+			return cached.url
+		}
+
+		signed := sign(rawURL, now)
+		cache[rawURL] = cachedSignature{url: signed, renewAt: now.Add(ttl / 2)}
+		return signed
+	}
+}
+
+func presignObjectURL(scheme signatureScheme, config ObjectStoreConfig, endpoint string, rawURL string, now time.Time, ttl time.Duration) string {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return rawURL
+	}
+
+	now = now.UTC()
+	date := now.Format("20060102")
+	timestamp := now.Format("20060102T150405Z")
+	scope := strings.Join([]string{date, config.Region, scheme.service, scheme.terminator}, "/")
+
+	query := map[string]string{
+		scheme.queryPrefix + "Algorithm":     scheme.algorithm,
+		scheme.queryPrefix + "Credential":    config.AccessKeyID + "/" + scope,
+		scheme.queryPrefix + "Date":          timestamp,
+		scheme.queryPrefix + "Expires":       strconv.Itoa(int(ttl.Seconds())),
+		scheme.queryPrefix + "SignedHeaders": "host",
+	}
+	if config.SessionToken != "" {
+		query[scheme.queryPrefix+"Security-Token"] = config.SessionToken
+	}
+
+	for name, values := range objectQuery(rawURL) {
+		if _, ok := query[name]; !ok {
+			query[name] = values[0]
+		}
+	}
+
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, uriEscape(name, true)+"="+uriEscape(query[name], true))
+	}
+
+	canonicalQuery := strings.Join(pairs, "&")
+	canonicalPath := uriEscape(strings.TrimSuffix(endpointURL.Path, "/")+"/"+strings.TrimPrefix(objectKey(rawURL), "/"), false)
+	canonicalRequest := strings.Join([]string{
+		"GET",
+		canonicalPath,
+		canonicalQuery,
+		"host:" + endpointURL.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{scheme.algorithm, timestamp, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := []byte(scheme.keyPrefix + config.SecretAccessKey)
+	for _, part := range []string{date, config.Region, scheme.service, scheme.terminator} {
+		key = hmacSHA256(key, part)
+	}
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	return fmt.Sprintf("%s://%s%s?%s&%sSignature=%s", endpointURL.Scheme, endpointURL.Host, canonicalPath, canonicalQuery, scheme.queryPrefix, signature)
+}
+
+func objectKey(rawURL string) string {
+	assetURL, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	return assetURL.Path
+}
+
+func objectQuery(rawURL string) url.Values {
+	assetURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+
+	return assetURL.Query()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func uriEscape(value string, escapeSlash bool) string {
+	var builder strings.Builder
+	for _, b := range []byte(value) {
+		unreserved := b >= 'A' && b <= 'Z' || b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || strings.IndexByte("-_.~", b) >= 0
+		if unreserved || b == '/' && !escapeSlash {
+			builder.WriteByte(b)
+			continue
+		}
+
+		fmt.Fprintf(&builder, "%%%02X", b)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,122 @@
+package goviteparser
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type SourceMapMode int
+
+const (
+	SourceMapKeep SourceMapMode = iota
+	SourceMapStrip
+	SourceMapAbsolute
+)
+
+var ErrAssetTooLarge = errors.New("asset exceeds the data uri size limit")
+
+var sourceMapComment = regexp.MustCompile(`(?:^|\n)[ \t]*(?://[#@][ \t]*sourceMappingURL=([^\s'"]+)|/\*[#@][ \t]*sourceMappingURL=([^\s*]+)[ \t]*\*/)\s*$`)
+
+func (vite *ViteManifestInfo) Content(entry string) (string, error) {
+	file, ok := vite.assetFile(entry)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrEntryNotFound, entry)
+	}
+
+	filePath := filepath.Join(vite.config.buildPath(), filepath.FromSlash(file))
+	content, err := vite.config.fileSystem().ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("read content of %s: %w", entry, err)
+	}
+
+	return rewriteSourceMap(string(content), vite.config.OutDir, file, vite.config.SourceMaps), nil
+}
+
+func (vite *ViteManifestInfo) AssetDataURI(asset string, maxBytes int64) (string, error) {
+	file, ok := vite.assetFile(asset)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrAssetNotFound, asset)
+	}
+
+	filePath := filepath.Join(vite.config.buildPath(), filepath.FromSlash(file))
+	info, err := vite.config.fileSystem().Stat(filePath)
+	if err != nil {
+		return "", fmt.Errorf("stat asset %s: %w", asset, err)
+	}
+
+	if info.Size() > maxBytes {
+		return "", fmt.Errorf("%w: %s is %d bytes", ErrAssetTooLarge, asset, info.Size())
+	}
+
+	content, err := vite.config.fileSystem().ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("read asset %s: %w", asset, err)
+	}
+
+	mimeType := mime.TypeByExtension(path.Ext(file))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(content)
+	}
+
+	mimeType = strings.ReplaceAll(mimeType, " ", "")
+
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(content)), nil
+}
+
+func (config Config) buildPath() string {
+	if config.BuildPath != "" {
+		return config.BuildPath
+	}
+
+	return manifestBuildDir(config.ManifestPath)
+}
+
+func manifestBuildDir(manifestPath string) string {
+	dir := filepath.Dir(manifestPath)
+	if filepath.Base(dir) == ".vite" {
+		dir = filepath.Dir(dir)
+	}
+
+	return dir
+}
+
+func rewriteSourceMap(content string, prefix string, file string, mode SourceMapMode) string {
+	if mode == SourceMapKeep {
+		return content
+	}
+
+	match := sourceMapComment.FindStringSubmatchIndex(content)
+	if match == nil {
+		return content
+	}
+
+	if mode == SourceMapStrip {
+		return content[:match[0]]
+	}
+
+	isCSS := match[4] != -1
+	mapURL := ""
+	if isCSS {
+		mapURL = content[match[4]:match[5]]
+	} else {
+		mapURL = content[match[2]:match[3]]
+	}
+
+	if strings.HasPrefix(mapURL, "data:") || strings.Contains(mapURL, "://") || strings.HasPrefix(mapURL, "/") {
+		return content
+	}
+
+	mapURL = prefix + path.Join(path.Dir(file), mapURL)
+	if isCSS {
+		return content[:match[0]] + fmt.Sprintf("\n/*# sourceMappingURL=%s */", mapURL)
+	}
+
+	return content[:match[0]] + fmt.Sprintf("\n//# sourceMappingURL=%s", mapURL)
+}
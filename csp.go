@@ -0,0 +1,111 @@
+package goviteparser
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type (
+	nonceContextKey struct{}
+
+	cspResponseWriter struct {
+		http.ResponseWriter
+		nonce       string
+		wroteHeader bool
+	}
+)
+
+var cspHeaders = []string{"Content-Security-Policy", "Content-Security-Policy-Report-Only"}
+
+func (vite *ViteManifestInfo) NonceMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			nonce, err := vite.GenerateNonce()
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), nonceContextKey{}, nonce)
+			writer := &cspResponseWriter{ResponseWriter: w, nonce: nonce}
+			next.ServeHTTP(writer, r.WithContext(ctx))
+			writer.appendNonce()
+		})
+	}
+}
+
+func NonceFromContext(ctx context.Context) string {
+	nonce, _ := ctx.Value(nonceContextKey{}).(string)
+	return nonce
+}
+
+func (w *cspResponseWriter) WriteHeader(statusCode int) {
+	w.appendNonce()
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *cspResponseWriter) appendNonce() {
+	if w.wroteHeader {
+		return
+	}
+
+	w.wroteHeader = true
+	for _, name := range cspHeaders {
+		if policy := w.Header().Get(name); policy != "" {
+			w.Header().Set(name, appendCSPNonce(policy, w.nonce))
+		}
+	}
+}
+
+func (w *cspResponseWriter) Write(content []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(content)
+}
+
+func (w *cspResponseWriter) Flush() {
+	w.appendNonce()
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *cspResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func appendCSPNonce(policy string, nonce string) string {
+	source := "'nonce-" + nonce + "'"
+	directives := strings.Split(policy, ";")
+	found := map[string]int{}
+	for i, directive := range directives {
+		fields := strings.Fields(directive)
+		if len(fields) > 0 {
+			found[strings.ToLower(fields[0])] = i
+		}
+	}
+
+	targets := map[int]bool{}
+	for _, names := range [][]string{{"script-src", "script-src-elem"}, {"style-src", "style-src-elem"}} {
+		governed := false
+		for _, name := range names {
+			if i, ok := found[name]; ok {
+				targets[i] = true
+				governed = true
+			}
+		}
+
+		if i, ok := found["default-src"]; ok && !governed {
+			targets[i] = true
+		}
+	}
+
+	for i := range directives {
+		if targets[i] {
+			directives[i] = strings.TrimRight(directives[i], " ") + " " + source
+		}
+	}
+
+	return strings.Join(directives, ";")
+}
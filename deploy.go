@@ -0,0 +1,73 @@
+package goviteparser
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const deployEventsHeartbeat = 30 * time.Second
+
+func (watcher *Watcher) DeployEvents() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		controller := http.NewResponseController(w)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+
+		hashes := watcher.subscribe()
+		defer watcher.unsubscribe(hashes)
+
+		writeDeployEvent(w, watcher.Vite().ManifestHash())
+		if err := controller.Flush(); err != nil {
+			return
+		}
+
+		heartbeat := time.NewTicker(deployEventsHeartbeat)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case hash := <-hashes:
+				writeDeployEvent(w, hash)
+			case <-heartbeat.C:
+				io.WriteString(w, ": heartbeat\n\n")
+			}
+
+			if err := controller.Flush(); err != nil {
+				return
+			}
+		}
+	})
+}
+
+func (watcher *Watcher) subscribe() chan string {
+	watcher.mu.Lock()
+	defer watcher.mu.Unlock()
+
+	if watcher.subscribers == nil {
+		watcher.subscribers = make(map[chan string]struct{})
+	}
+
+	subscriber := make(chan string, 1)
+	watcher.subscribers[subscriber] = struct{}{}
+	return subscriber
+}
+
+func (watcher *Watcher) unsubscribe(subscriber chan string) {
+	watcher.mu.Lock()
+	defer watcher.mu.Unlock()
+
+	delete(watcher.subscribers, subscriber)
+}
+
+func writeDeployEvent(w io.Writer, hash string) {
+	if hash == "" {
+		return
+	}
+
+	fmt.Fprintf(w, "event: manifest\ndata: %s\n\n", hash)
+}
@@ -0,0 +1,75 @@
+package goviteparser
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+var ErrDevServerNotRunning = errors.New("dev server is not running")
+
+func (vite *ViteManifestInfo) DevURL(source string) (string, error) {
+	if !vite.IsDev() {
+		return "", fmt.Errorf("%w: %s", ErrDevServerNotRunning, source)
+	}
+
+	return vite.devURL(source)
+}
+
+func (vite *ViteManifestInfo) HotOrigin() (string, error) {
+	if !vite.IsDev() {
+		return "", ErrDevServerNotRunning
+	}
+
+	return vite.Origin, nil
+}
+
+func (vite *ViteManifestInfo) HotAssetURL(asset string) (string, error) {
+	return vite.DevURL(asset)
+}
+
+func (vite *ViteManifestInfo) devURL(source string) (string, error) {
+	devURL, err := url.JoinPath(vite.config.devOrigin(vite.Origin), vite.devPath(source))
+	if err != nil {
+		return "", fmt.Errorf("resolve dev url for %s: %w", source, err)
+	}
+
+	return devURL, nil
+}
+
+func (config Config) devOrigin(origin string) string {
+	if config.DevBase == "" || origin == "" {
+		return origin
+	}
+
+	return joinOrigin(origin, config.DevBase)
+}
+
+func (vite *ViteManifestInfo) devPath(source string) string {
+	root := vite.config.Root
+	if root != "" {
+		if absolute, err := filepath.Abs(root); err == nil {
+			root = absolute
+		}
+	}
+
+	local := filepath.FromSlash(source)
+	if !filepath.IsAbs(local) {
+		if root == "" || !strings.HasPrefix(filepath.ToSlash(filepath.Clean(local)), "../") {
+			return source
+		}
+
+		local = filepath.Join(root, local)
+	}
+
+	if root != "" {
+		relative, err := filepath.Rel(root, local)
+		if err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(relative)
+		}
+	}
+
+	return "/@fs/" + strings.TrimPrefix(filepath.ToSlash(filepath.Clean(local)), "/")
+}
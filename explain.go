@@ -0,0 +1,79 @@
+package goviteparser
+
+import (
+	"context"
+	"fmt"
+)
+
+type (
+	Explanation struct {
+		Resolved []ExplainedChunk
+		Skipped  []ExplainedChunk
+		Deduped  []ExplainedChunk
+	}
+
+	ExplainedChunk struct {
+		Key    string
+		File   string
+		Reason string
+	}
+)
+
+func (vite *ViteManifestInfo) Explain(entrypoints []string, opts ...InvokeOption) (Explanation, error) {
+	explanation := Explanation{}
+	opts = append(opts, func(options *invokeOptions) {
+		options.explanation = &explanation
+	})
+
+	_, err := vite.InvokeCtx(context.Background(), entrypoints, opts...)
+	return explanation, err
+}
+
+func (options *invokeOptions) explainResolve(kind TagKind, key string, file string) {
+	if options.explanation == nil {
+		return
+	}
+
+	options.explanation.Resolved = append(options.explanation.Resolved, ExplainedChunk{
+		Key:    key,
+		File:   file,
+		Reason: fmt.Sprintf("%s for %s", tagKindName(kind), options.entry),
+	})
+}
+
+func (options *invokeOptions) explainDedupe(kind TagKind, key string, file string, first string) {
+	if options.explanation == nil {
+		return
+	}
+
+	options.explanation.Deduped = append(options.explanation.Deduped, ExplainedChunk{
+		Key:    key,
+		File:   file,
+		Reason: fmt.Sprintf("%s for %s, already rendered for %s", tagKindName(kind), options.entry, first),
+	})
+}
+
+func (options *invokeOptions) explainSkip(key string, file string, reason string) {
+	if options.explanation == nil {
+		return
+	}
+
+	options.explanation.Skipped = append(options.explanation.Skipped, ExplainedChunk{
+		Key:    key,
+		File:   file,
+		Reason: reason,
+	})
+}
+
+func tagKindName(kind TagKind) string {
+	switch kind {
+	case TagPreload:
+		return "modulepreload"
+	case TagStyle:
+		return "stylesheet"
+	case TagFont:
+		return "font preload"
+	default:
+		return "script"
+	}
+}
@@ -0,0 +1,71 @@
+package goviteparser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+var ErrInvalidPageName = errors.New("invalid page name")
+
+func (vite *ViteManifestInfo) ExportTags(outDir string, pages map[string][]string) error {
+	names := make([]string, 0, len(pages))
+	for name := range pages {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		fileName := filepath.FromSlash(name) + ".html"
+		if !filepath.IsLocal(fileName) {
+			return fmt.Errorf("%w: %s", ErrInvalidPageName, name)
+		}
+
+		tags, err := vite.invoke(context.Background(), pages[name], false, WithStrict(true))
+		if err != nil {
+			return fmt.Errorf("render tags for page %s: %w", name, err)
+		}
+
+		filePath := filepath.Join(outDir, fileName)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			return fmt.Errorf("create directory for page %s: %w", name, err)
+		}
+
+		if err := os.WriteFile(filePath, []byte(tags), 0o644); err != nil {
+			return fmt.Errorf("write tags for page %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (vite *ViteManifestInfo) ExportAssetMap(w io.Writer) error {
+	options := vite.config.urlOptions()
+	assets := make(map[string]string)
+	for key, entryInfo := range vite.Manifest {
+		assets[key] = options.assetURL(key, vite.config.OutDir, entryInfo.File)
+	}
+
+	for _, entryInfo := range vite.Manifest {
+		files := append(append(append([]string{}, entryInfo.CSS...), entryInfo.Assets...), entryInfo.scripts...)
+		for _, file := range files {
+			if _, ok := assets[file]; !ok {
+				assets[file] = options.assetURL(file, vite.config.OutDir, file)
+			}
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(assets); err != nil {
+		return fmt.Errorf("encode asset map: %w", err)
+	}
+
+	return nil
+}
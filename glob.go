@@ -0,0 +1,48 @@
+package goviteparser
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+func (vite *ViteManifestInfo) Glob(pattern string) ([]string, error) {
+	if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+		return nil, fmt.Errorf("invalid glob %s: %w", pattern, err)
+	}
+
+	patternSegments := strings.Split(pattern, "/")
+	keys := []string{}
+	for key := range vite.Manifest {
+		if matchGlob(patternSegments, strings.Split(key, "/")) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func matchGlob(pattern []string, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchGlob(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+
+	matched, _ := path.Match(pattern[0], segments[0])
+	return matched && matchGlob(pattern[1:], segments[1:])
+}
@@ -0,0 +1,191 @@
+package goviteparser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func (vite *ViteManifestInfo) DetectCycles() [][]string {
+	keys := make([]string, 0, len(vite.Manifest))
+	for key := range vite.Manifest {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int)
+	seen := make(map[string]bool)
+	stack := []string{}
+	cycles := [][]string{}
+
+	var visit func(key string)
+	visit = func(key string) {
+		state[key] = visiting
+		stack = append(stack, key)
+
+		for _, importPath := range vite.Manifest[key].Imports {
+			if _, ok := vite.Manifest[importPath]; !ok {
+				continue
+			}
+
+			switch state[importPath] {
+			case unvisited:
+				visit(importPath)
+			case visiting:
+				cycle := cycleFrom(stack, importPath)
+				id := strings.Join(cycle, "\x00")
+				if !seen[id] {
+					seen[id] = true
+					cycles = append(cycles, cycle)
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[key] = visited
+	}
+
+	for _, key := range keys {
+		if state[key] == unvisited {
+			visit(key)
+		}
+	}
+
+	return cycles
+}
+
+func cycleFrom(stack []string, start string) []string {
+	index := 0
+	for i, key := range stack {
+		if key == start {
+			index = i
+			break
+		}
+	}
+
+	members := stack[index:]
+	smallest := 0
+	for i, key := range members {
+		if key < members[smallest] {
+			smallest = i
+		}
+	}
+
+	cycle := make([]string, 0, len(members)+1)
+	cycle = append(cycle, members[smallest:]...)
+	cycle = append(cycle, members[:smallest]...)
+	return append(cycle, cycle[0])
+}
+
+func (vite *ViteManifestInfo) DynamicImports(entry string) ([]ResolvedChunk, error) {
+	entry = vite.entryKey(vite.Manifest, entry)
+	if _, ok := vite.Manifest[entry]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrEntryNotFound, entry)
+	}
+
+	static := staticImports(vite.Manifest, entry)
+	discovered := make(map[string]bool)
+	for _, key := range static {
+		discovered[key] = true
+	}
+
+	chunks := []ResolvedChunk{}
+	options := vite.config.urlOptions()
+
+	var walk func(keys []string)
+	walk = func(keys []string) {
+		for _, key := range keys {
+			chunk, ok := vite.Manifest[key]
+			if !ok || discovered[key] {
+				continue
+			}
+
+			discovered[key] = true
+			chunks = append(chunks, ResolvedChunk{
+				Key:  key,
+				File: chunk.File,
+				URL:  options.assetURL(key, vite.config.OutDir, chunk.File),
+			})
+
+			walk(chunk.Imports)
+			walk(chunk.DynamicImports)
+		}
+	}
+
+	for _, key := range static {
+		walk(vite.Manifest[key].DynamicImports)
+	}
+
+	return chunks, nil
+}
+
+func staticImports(manifest Manifest, entry string) []string {
+	discovered := map[string]bool{}
+	keys := []string{}
+
+	var walk func(key string)
+	walk = func(key string) {
+		if discovered[key] {
+			return
+		}
+
+		chunk, ok := manifest[key]
+		if !ok {
+			return
+		}
+
+		discovered[key] = true
+		keys = append(keys, key)
+		for _, importPath := range chunk.Imports {
+			walk(importPath)
+		}
+	}
+
+	walk(entry)
+	return keys
+}
+
+func importDepths(manifest Manifest, imports []string) map[string]int {
+	depths := make(map[string]int)
+	level := imports
+	for depth := 1; len(level) > 0; depth++ {
+		next := []string{}
+		for _, key := range level {
+			chunk, ok := manifest[key]
+			if _, seen := depths[key]; !ok || seen {
+				continue
+			}
+
+			depths[key] = depth
+			next = append(next, chunk.Imports...)
+		}
+
+		level = next
+	}
+
+	return depths
+}
+
+type missingChunk struct {
+	importer string
+	key      string
+}
+
+func missingImports(manifest Manifest, entry string) []missingChunk {
+	missing := []missingChunk{}
+	for _, key := range staticImports(manifest, entry) {
+		for _, importPath := range manifest[key].Imports {
+			if _, ok := manifest[importPath]; !ok {
+				missing = append(missing, missingChunk{importer: key, key: importPath})
+			}
+		}
+	}
+
+	return missing
+}
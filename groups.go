@@ -0,0 +1,114 @@
+package goviteparser
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+)
+
+type (
+	AssetGroups struct {
+		EntryScripts []GroupedAsset
+		SharedChunks []GroupedAsset
+		Styles       []GroupedAsset
+		Fonts        []GroupedAsset
+		Other        []GroupedAsset
+	}
+
+	GroupedAsset struct {
+		Chunk string
+		File  string
+		URL   string
+		Size  int64
+	}
+
+	pageFile struct {
+		chunk string
+		file  string
+		entry bool
+	}
+)
+
+func (vite *ViteManifestInfo) AssetGroups(entrypoints ...string) (AssetGroups, error) {
+	files, err := vite.pageFiles(entrypoints)
+	if err != nil {
+		return AssetGroups{}, err
+	}
+
+	groups := AssetGroups{}
+	options := vite.config.urlOptions()
+	for _, file := range files {
+		info, err := vite.config.fileSystem().Stat(filepath.Join(vite.config.buildPath(), filepath.FromSlash(file.file)))
+		if err != nil {
+			return AssetGroups{}, fmt.Errorf("stat asset %s: %w", file.file, err)
+		}
+
+		asset := GroupedAsset{
+			Chunk: file.chunk,
+			File:  file.file,
+			URL:   options.assetURL(file.chunk, vite.config.OutDir, file.file),
+			Size:  info.Size(),
+		}
+
+		preloadType, _ := vite.config.preloadType(file.file)
+		extension := path.Ext(file.file)
+		switch {
+		case inArray(extension, scriptExtensions) && file.entry:
+			groups.EntryScripts = append(groups.EntryScripts, asset)
+		case inArray(extension, scriptExtensions):
+			groups.SharedChunks = append(groups.SharedChunks, asset)
+		case inArray(extension, styleExtensions):
+			groups.Styles = append(groups.Styles, asset)
+		case preloadType.As == "font":
+			groups.Fonts = append(groups.Fonts, asset)
+		default:
+			groups.Other = append(groups.Other, asset)
+		}
+	}
+
+	return groups, nil
+}
+
+func (groups AssetGroups) TotalSize() int64 {
+	total := int64(0)
+	for _, group := range [][]GroupedAsset{groups.EntryScripts, groups.SharedChunks, groups.Styles, groups.Fonts, groups.Other} {
+		for _, asset := range group {
+			total += asset.Size
+		}
+	}
+
+	return total
+}
+
+func (vite *ViteManifestInfo) pageFiles(entrypoints []string) ([]pageFile, error) {
+	files := []pageFile{}
+	seen := make(map[string]bool)
+	add := func(chunk string, file string, entry bool) {
+		if file == "" || seen[file] {
+			return
+		}
+
+		seen[file] = true
+		files = append(files, pageFile{chunk: chunk, file: file, entry: entry})
+	}
+
+	for _, entry := range vite.entryKeys(vite.Manifest, entrypoints) {
+		if _, ok := vite.Manifest[entry]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrEntryNotFound, entry)
+		}
+
+		for _, key := range staticImports(vite.Manifest, entry) {
+			chunk := vite.Manifest[key]
+			add(key, chunk.File, key == entry)
+			for _, file := range chunk.CSS {
+				add(key, file, false)
+			}
+
+			for _, file := range chunk.Assets {
+				add(key, file, false)
+			}
+		}
+	}
+
+	return files, nil
+}
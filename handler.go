@@ -0,0 +1,63 @@
+package goviteparser
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+)
+
+type (
+	ShellConfig struct {
+		Title       string
+		Lang        string
+		Meta        []ShellMeta
+		Entrypoints []string
+		AppID       string
+	}
+
+	ShellMeta struct {
+		Name    string
+		Content string
+	}
+)
+
+func (vite *ViteManifestInfo) ShellHandler(config ShellConfig) http.Handler {
+	lang := config.Lang
+	if lang == "" {
+		lang = "en"
+	}
+
+	meta := ""
+	for _, item := range config.Meta {
+		meta += fmt.Sprintf(`<meta name="%s" content="%s" />`, html.EscapeString(item.Name), html.EscapeString(item.Content))
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tags, err := vite.InvokeCtx(r.Context(), config.Entrypoints)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		if vite.IsDev() {
+			tags = vite.RenderClientTag() + tags
+		}
+
+		var body strings.Builder
+		body.WriteString("<!DOCTYPE html>")
+		fmt.Fprintf(&body, `<html lang="%s">`, html.EscapeString(lang))
+		body.WriteString(`<head><meta charset="UTF-8" /><meta name="viewport" content="width=device-width, initial-scale=1.0" />`)
+		fmt.Fprintf(&body, "<title>%s</title>", html.EscapeString(config.Title))
+		body.WriteString(meta)
+		body.WriteString(tags)
+		body.WriteString("</head><body>")
+		if config.AppID != "" {
+			fmt.Fprintf(&body, `<div id="%s"></div>`, html.EscapeString(config.AppID))
+		}
+		body.WriteString("</body></html>")
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(body.String()))
+	})
+}
@@ -0,0 +1,42 @@
+package goviteparser
+
+import (
+	"fmt"
+	"html"
+)
+
+type ResourceHint struct {
+	Origin      string
+	Rel         string
+	CrossOrigin string
+}
+
+func (options *invokeOptions) resourceHints(hints []ResourceHint) string {
+	tags := ""
+	for _, hint := range hints {
+		rel := hint.Rel
+		if rel == "" {
+			rel = "preconnect"
+		}
+
+		attributes := []Attribute{{Name: "crossorigin", Value: crossOriginValue(hint.CrossOrigin)}}
+		if nonce := options.nonceFor(TagPreload); nonce != "" {
+			attributes = append(attributes, Attribute{Name: "nonce", Value: nonce})
+		}
+
+		tags += fmt.Sprintf(`<link rel="%s" href="%s"%s />`, html.EscapeString(rel), html.EscapeString(hint.Origin), renderAttributes(attributes))
+	}
+
+	return tags
+}
+
+func crossOriginValue(crossOrigin string) any {
+	switch crossOrigin {
+	case "":
+		return nil
+	case "anonymous":
+		return true
+	default:
+		return crossOrigin
+	}
+}
@@ -0,0 +1,77 @@
+package goviteparser
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+const (
+	reactRefreshScriptName = "react-refresh.js"
+	cdnFailoverScriptName  = "cdn-failover.js"
+)
+
+func (vite *ViteManifestInfo) ScriptHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		source := ""
+		switch path.Base(r.URL.Path) {
+		case reactRefreshScriptName:
+			if vite.IsDev() {
+				source = reactRefreshSource(vite.config.devOrigin(vite.Origin))
+			}
+		case cdnFailoverScriptName:
+			if vite.config.CDNFallback != "" {
+				source = cdnFailoverSource([]string{vite.config.OutDir, vite.config.CDNFallback})
+			}
+		}
+
+		if source == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		fmt.Fprint(w, source)
+	})
+}
+
+func (config Config) reactRefreshTag(origin string, nonce string) string {
+	if !config.NoInlineScripts {
+		tag := createReactRefreshTag(origin, nonce)
+		if config.MinifyHTML {
+			tag = minifyHTML(tag)
+		}
+
+		return tag
+	}
+
+	src, ok := config.scriptURL(reactRefreshScriptName)
+	if origin == "" || !ok {
+		return ""
+	}
+
+	return createScriptTag(src, nonceAttribute(nonce))
+}
+
+func (config Config) cdnFailoverTag(buildDirectory string, nonce string) string {
+	if !config.NoInlineScripts {
+		return CDNFailoverScript([]string{buildDirectory, config.CDNFallback}, nonce)
+	}
+
+	src, ok := config.scriptURL(cdnFailoverScriptName)
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf(`<script src="%s"%s></script>`, src, nonceAttribute(nonce))
+}
+
+func (config Config) scriptURL(name string) (string, bool) {
+	if config.ScriptPath == "" {
+		return "", false
+	}
+
+	src, err := url.JoinPath(config.ScriptPath, name)
+	return src, err == nil
+}
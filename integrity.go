@@ -0,0 +1,79 @@
+package goviteparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (config Config) applyIntegrity(manifest Manifest, content []byte) error {
+	keys := config.IntegrityKeys
+	if len(keys) == 0 {
+		keys = []string{"integrity"}
+	}
+
+	var chunks map[string]map[string]any
+	if err := config.unmarshal(content, &chunks); err != nil {
+		return err
+	}
+
+	for name, fields := range chunks {
+		entryInfo, ok := manifest[name]
+		if !ok {
+			continue
+		}
+
+		entryInfo.Integrity = ""
+		for _, key := range keys {
+			value, ok := fields[key]
+			if !ok {
+				continue
+			}
+
+			integrity, err := parseIntegrity(value)
+			if err != nil {
+				return fmt.Errorf("chunk %s: %w", name, err)
+			}
+
+			entryInfo.Integrity = integrity
+			break
+		}
+
+		manifest[name] = entryInfo
+	}
+
+	return nil
+}
+
+func parseIntegrity(value any) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return strings.Join(strings.Fields(value), " "), nil
+	case []any:
+		hashes := make([]string, 0, len(value))
+		for _, hash := range value {
+			hash, ok := hash.(string)
+			if !ok {
+				return "", fmt.Errorf("integrity must be a string or an array of strings")
+			}
+
+			hashes = append(hashes, hash)
+		}
+
+		return strings.Join(hashes, " "), nil
+	default:
+		return "", fmt.Errorf("integrity must be a string or an array of strings")
+	}
+}
+
+func indexIntegrities(manifest Manifest) map[string]string {
+	integrities := make(map[string]string)
+	for _, entryInfo := range manifest {
+		if entryInfo.Integrity != "" {
+			integrities[entryInfo.File] = entryInfo.Integrity
+		}
+	}
+
+	return integrities
+}
@@ -0,0 +1,541 @@
+package goviteparser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"text/template"
+)
+
+type (
+	InvokeOption      func(*invokeOptions)
+	TagKind           int
+	AssetPathResolver func(url string) string
+
+	invokeOptions struct {
+		environment    string
+		manifest       Manifest
+		buildDirectory string
+		resolver       AssetPathResolver
+		rewriter       func(chunk string, file string) string
+		nonce          string
+		withoutNonce   []TagKind
+		preloadDepth   int
+		entryPriority  string
+		sharedPriority string
+		strict         bool
+		templates      map[TagKind]*template.Template
+		integrities    map[string]string
+		manifestHash   string
+		preloadTypes   map[string]PreloadType
+		preloadFonts   bool
+		rendered       map[string]string
+		debug          bool
+		entry          string
+		reported       map[string]bool
+
+		crossOrigin     string
+		onAssetResolved func(entry, file, url string)
+		beforeTag       func(tag *Tag) bool
+		afterTag        func(tag Tag, html string) string
+		collect         func(tag *Tag)
+		explanation     *Explanation
+		err             error
+	}
+
+	TagData struct {
+		URL           string
+		Nonce         string
+		Integrity     string
+		FetchPriority string
+		CrossOrigin   string
+		Classic       bool
+	}
+)
+
+const (
+	TagPreload TagKind = iota
+	TagStyle
+	TagScript
+	TagFont
+)
+
+var (
+	ErrEntryNotFound      = errors.New("entry not found in manifest")
+	ErrUnknownEnvironment = errors.New("unknown environment")
+	ErrChunkNotFound      = errors.New("imported chunk not found in manifest")
+)
+
+func WithBuildDirectory(buildDirectory string) InvokeOption {
+	return func(options *invokeOptions) {
+		options.buildDirectory = buildDirectory
+	}
+}
+
+func WithEnvironment(environment string) InvokeOption {
+	return func(options *invokeOptions) {
+		options.environment = environment
+	}
+}
+
+func WithNonce(nonce string) InvokeOption {
+	return func(options *invokeOptions) {
+		options.nonce = nonce
+	}
+}
+
+func WithoutNonce(kinds ...TagKind) InvokeOption {
+	return func(options *invokeOptions) {
+		options.withoutNonce = append(options.withoutNonce, kinds...)
+	}
+}
+
+func WithTagTemplates(scriptTmpl, styleTmpl, preloadTmpl string) InvokeOption {
+	return func(options *invokeOptions) {
+		sources := map[TagKind]string{
+			TagScript:  scriptTmpl,
+			TagStyle:   styleTmpl,
+			TagPreload: preloadTmpl,
+		}
+
+		options.templates = make(map[TagKind]*template.Template)
+		for kind, source := range sources {
+			if source == "" {
+				continue
+			}
+
+			tmpl, err := template.New("tag").Parse(source)
+			if err != nil {
+				options.err = fmt.Errorf("parse tag template: %w", err)
+				return
+			}
+
+			options.templates[kind] = tmpl
+		}
+	}
+}
+
+func WithPreloadDepth(depth int) InvokeOption {
+	return func(options *invokeOptions) {
+		options.preloadDepth = depth
+	}
+}
+
+func WithFetchPriority(entry string, shared string) InvokeOption {
+	return func(options *invokeOptions) {
+		options.entryPriority = entry
+		options.sharedPriority = shared
+	}
+}
+
+func WithStrict(strict bool) InvokeOption {
+	return func(options *invokeOptions) {
+		options.strict = strict
+	}
+}
+
+func (vite *ViteManifestInfo) Invoke(entrypoints ...string) (string, error) {
+	return vite.InvokeCtx(context.Background(), entrypoints)
+}
+
+func (vite *ViteManifestInfo) With(opts ...InvokeOption) *ViteManifestInfo {
+	clone := *vite
+	clone.defaults = append(append([]InvokeOption{}, vite.defaults...), opts...)
+	return &clone
+}
+
+func (vite *ViteManifestInfo) InvokeCtx(ctx context.Context, entrypoints []string, opts ...InvokeOption) (string, error) {
+	return vite.invoke(ctx, entrypoints, vite.IsDev(), opts...)
+}
+
+func (vite *ViteManifestInfo) invoke(ctx context.Context, entrypoints []string, dev bool, opts ...InvokeOption) (string, error) {
+	if profile := profileFromContext(ctx); profile != "" {
+		opts = append([]InvokeOption{WithEnvironment(profile)}, opts...)
+	}
+
+	if nonce := NonceFromContext(ctx); nonce != "" {
+		opts = append([]InvokeOption{WithNonce(nonce)}, opts...)
+	}
+
+	options, err := vite.resolveOptions(opts)
+	if err != nil {
+		return "", err
+	}
+
+	tags := ""
+	if !vite.IsDev() && vite.config.ManifestHashMeta {
+		tags += manifestHashMeta(options.manifestHash)
+	}
+
+	tags += options.resourceHints(vite.config.ResourceHints)
+	if dev && vite.IsDev() && vite.config.AutoReactRefresh {
+		tags += vite.config.reactRefreshTag(vite.config.devOrigin(vite.Origin), options.nonceFor(TagScript))
+	}
+
+	if !dev && vite.config.CDNFallback != "" {
+		tags += vite.config.cdnFailoverTag(options.buildDirectory, options.nonceFor(TagScript))
+	}
+
+	var collected chunkTags
+	for _, entry := range entrypoints {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		entry = vite.entryKey(options.manifest, entry)
+		options.err = nil
+		options.entry = entry
+		if dev {
+			tag, err := vite.entryDevTag(entry, &options)
+			if err != nil {
+				options.explainSkip(entry, "", err.Error())
+				if options.strict {
+					return "", err
+				}
+
+				vite.config.warn(err)
+				continue
+			}
+
+			collected.script += tag
+			continue
+		}
+
+		entryTags, err := vite.entryTag(entry, &options)
+		if err != nil {
+			options.explainSkip(entry, "", err.Error())
+			if options.strict {
+				return "", err
+			}
+
+			vite.config.warn(err)
+			continue
+		}
+
+		collected = collected.merge(entryTags)
+	}
+
+	tags = vite.config.tagsGenerated(entrypoints, tags+options.renderWithSnippets(collected, vite.config.Snippets))
+
+	if options.debug {
+		source := "manifest=" + options.manifestHash
+		if vite.IsDev() {
+			source = "origin=" + vite.Origin
+		}
+
+		start := markerComment(fmt.Sprintf("vite:start entry=%s %s", strings.Join(entrypoints, ","), source))
+		tags = start + tags + markerComment("vite:end")
+	}
+
+	return markerComment(vite.config.BeginMarker) + tags + markerComment(vite.config.EndMarker), nil
+}
+
+func markerComment(marker string) string {
+	if marker == "" {
+		return ""
+	}
+
+	for strings.Contains(marker, "--") {
+		marker = strings.ReplaceAll(marker, "--", "- -")
+	}
+
+	return "<!-- " + marker + " -->"
+}
+
+func (vite *ViteManifestInfo) resolveOptions(opts []InvokeOption) (invokeOptions, error) {
+	options := invokeOptions{}
+	for _, opt := range vite.defaults {
+		opt(&options)
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.err != nil {
+		return options, options.err
+	}
+
+	options.manifest = vite.Manifest
+	options.integrities = vite.integrities
+	options.manifestHash = vite.manifestHash
+	options.rendered = make(map[string]string)
+	options.resolver = vite.config.AssetPathResolver
+	options.rewriter = vite.config.ChunkURLRewriter
+	options.debug = vite.config.Debug
+	options.crossOrigin = vite.config.CrossOrigin
+	options.onAssetResolved = vite.config.OnAssetResolved
+	options.beforeTag = vite.config.BeforeTag
+	options.afterTag = vite.config.AfterTag
+	options.preloadTypes = vite.config.PreloadTypes
+	options.preloadFonts = vite.config.PreloadFonts
+	buildDirectory := vite.config.OutDir
+	if options.environment == "" {
+		options.environment = vite.config.Profile
+	}
+
+	if options.environment != "" {
+		environment, ok := vite.config.Environments[options.environment]
+		if !ok {
+			return options, fmt.Errorf("%w: %s", ErrUnknownEnvironment, options.environment)
+		}
+
+		options.manifest = vite.environments[options.environment]
+		options.integrities = vite.environmentIntegrities[options.environment]
+		options.manifestHash = vite.environmentHashes[options.environment]
+		buildDirectory = environment.OutDir
+	}
+
+	if options.buildDirectory == "" {
+		options.buildDirectory = buildDirectory
+	}
+
+	return options, nil
+}
+
+func (vite *ViteManifestInfo) entryTag(entry string, options *invokeOptions) (chunkTags, error) {
+	if _, ok := options.manifest[entry]; !ok {
+		return chunkTags{}, fmt.Errorf("%w: %s", ErrEntryNotFound, entry)
+	}
+
+	for _, missing := range missingImports(options.manifest, entry) {
+		err := fmt.Errorf("%w: %s imported by %s", ErrChunkNotFound, missing.key, missing.importer)
+		if options.strict {
+			return chunkTags{}, err
+		}
+
+		options.explainSkip(missing.key, "", err.Error())
+		vite.config.warn(err)
+	}
+
+	tags := resolveTagEntry(options.manifest, entry, options.buildDirectory, options)
+	if options.err != nil {
+		return chunkTags{}, options.err
+	}
+
+	return tags, nil
+}
+
+func (vite *ViteManifestInfo) entryDevTag(input string, options *invokeOptions) (string, error) {
+	urlPath, err := vite.devURL(input)
+	if err != nil {
+		return "", err
+	}
+
+	var tag *Tag
+	rendered := ""
+	crossOrigin := Attribute{Name: "crossorigin", Value: crossOriginValue(vite.config.DevCrossOrigin)}
+	extension := path.Ext(input)
+	if inArray(extension, scriptExtensions) {
+		tag, rendered = options.renderTag(Tag{Kind: TagScript, Chunk: input, URL: urlPath}, input, crossOrigin)
+	} else if inArray(extension, styleExtensions) {
+		if vite.config.DirectDevCSS {
+			urlPath += "?direct"
+		}
+
+		tag, rendered = options.renderTag(Tag{Kind: TagStyle, Chunk: input, URL: urlPath}, input, crossOrigin)
+	} else {
+		options.explainSkip(input, input, "not a script or stylesheet")
+	}
+
+	if tag != nil {
+		options.resolved(input, tag.URL)
+	}
+
+	return rendered, options.err
+}
+
+func (config Config) urlOptions() invokeOptions {
+	return invokeOptions{resolver: config.AssetPathResolver, rewriter: config.ChunkURLRewriter}
+}
+
+func (options *invokeOptions) assetURL(key string, prefix string, file string) string {
+	if options.rewriter != nil {
+		file = options.rewriter(key, file)
+	}
+
+	url := prefix + escapePath(file)
+	if isAbsoluteURL(file) {
+		url = file
+	}
+
+	if options.resolver != nil {
+		return options.resolver(url)
+	}
+
+	return url
+}
+
+func (options *invokeOptions) nonceFor(kind TagKind) string {
+	for _, withoutNonce := range options.withoutNonce {
+		if withoutNonce == kind {
+			return ""
+		}
+	}
+
+	return options.nonce
+}
+
+func (options *invokeOptions) fileTag(kind TagKind, key string, prefix string, file string) string {
+	return options.assetTag(Tag{Kind: kind, Chunk: key}, prefix, file, "")
+}
+
+func (options *invokeOptions) scriptTag(key string, prefix string, file string, classic bool) string {
+	return options.assetTag(Tag{Kind: TagScript, Chunk: key, Classic: classic}, prefix, file, "")
+}
+
+func (options *invokeOptions) preloadTag(key string, prefix string, file string, fetchPriority string) string {
+	return options.assetTag(Tag{Kind: TagPreload, Chunk: key}, prefix, file, fetchPriority)
+}
+
+func (options *invokeOptions) assetTag(base Tag, prefix string, file string, fetchPriority string) string {
+	base.URL = options.assetURL(base.Chunk, prefix, file)
+	tag, rendered := options.renderTag(base, file,
+		Attribute{Name: "integrity", Value: options.integrityFor(file)},
+		Attribute{Name: "fetchpriority", Value: fetchPriority},
+		Attribute{Name: "crossorigin", Value: crossOriginValue(options.crossOrigin)},
+	)
+	if tag != nil {
+		options.resolved(file, tag.URL)
+	}
+
+	return options.annotate(base.Chunk, rendered)
+}
+
+func (options *invokeOptions) fontTags(key string, prefix string, assets []string) string {
+	if !options.preloadFonts {
+		return ""
+	}
+
+	tags := ""
+	for _, file := range assets {
+		preloadType, ok := preloadTypeFor(options.preloadTypes, file)
+		if !ok || preloadType.As != "font" {
+			continue
+		}
+
+		crossOrigin := crossOriginValue(options.crossOrigin)
+		if crossOrigin == nil {
+			crossOrigin = true
+		}
+
+		tag, rendered := options.renderTag(Tag{Kind: TagFont, Chunk: key, URL: options.assetURL(key, prefix, file)}, file,
+			Attribute{Name: "type", Value: preloadType.Type},
+			Attribute{Name: "crossorigin", Value: crossOrigin},
+		)
+		if tag != nil {
+			options.resolved(file, tag.URL)
+		}
+
+		tags += options.annotate(key, rendered)
+	}
+
+	return tags
+}
+
+func (options *invokeOptions) resolved(file string, url string) {
+	if options.onAssetResolved == nil || options.reported[url] {
+		return
+	}
+
+	if options.reported == nil {
+		options.reported = make(map[string]bool)
+	}
+
+	options.reported[url] = true
+	options.onAssetResolved(options.entry, file, url)
+}
+
+func (options *invokeOptions) annotate(key string, tag string) string {
+	if !options.debug || tag == "" {
+		return tag
+	}
+
+	return markerComment("vite:chunk "+key) + tag
+}
+
+func (options *invokeOptions) integrityFor(file string) string {
+	if options.integrities == nil {
+		options.integrities = indexIntegrities(options.manifest)
+	}
+
+	return options.integrities[file]
+}
+
+func (options *invokeOptions) renderTag(base Tag, file string, attributes ...Attribute) (*Tag, string) {
+	kind, key := base.Kind, base.Chunk
+	tag := &base
+	for _, attribute := range attributes {
+		if attribute.Value != nil && attribute.Value != "" {
+			tag.Set(attribute.Name, attribute.Value)
+		}
+	}
+
+	if nonce := options.nonceFor(kind); nonce != "" {
+		tag.Set("nonce", nonce)
+	}
+
+	if options.beforeTag != nil && !options.beforeTag(tag) {
+		options.explainSkip(key, file, tagKindName(kind)+" dropped by BeforeTag")
+		return nil, ""
+	}
+
+	if options.rendered != nil {
+		renderedKey := fmt.Sprintf("%d:%s", kind, tag.URL)
+		if first, ok := options.rendered[renderedKey]; ok {
+			options.explainDedupe(kind, key, file, first)
+			return nil, ""
+		}
+
+		options.rendered[renderedKey] = options.entry
+	}
+
+	if options.collect != nil {
+		options.collect(tag)
+	}
+
+	rendered := tag.Render()
+	if tmpl, ok := options.templates[kind]; ok {
+		var builder strings.Builder
+		data := TagData{
+			URL:           tag.URL,
+			Nonce:         tag.attributeString("nonce"),
+			Integrity:     tag.attributeString("integrity"),
+			FetchPriority: tag.attributeString("fetchpriority"),
+			CrossOrigin:   tag.crossOrigin(),
+			Classic:       tag.Classic,
+		}
+		if err := tmpl.Execute(&builder, data); err != nil {
+			options.err = fmt.Errorf("execute tag template: %w", err)
+			return nil, ""
+		}
+
+		rendered = builder.String()
+	}
+
+	if options.afterTag != nil {
+		rendered = options.afterTag(*tag, rendered)
+		if rendered == "" {
+			return nil, ""
+		}
+	}
+
+	options.explainResolve(kind, key, file)
+	return tag, rendered
+}
+
+func isAbsoluteURL(file string) bool {
+	if strings.HasPrefix(file, "//") {
+		return true
+	}
+
+	fileURL, err := url.Parse(file)
+	return err == nil && fileURL.IsAbs() && fileURL.Host != ""
+}
+
+func escapePath(filePath string) string {
+	return (&url.URL{Path: filePath}).EscapedPath()
+}
@@ -0,0 +1,151 @@
+package goviteparser
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	ManifestLoader interface {
+		Load(ctx context.Context) ([]byte, error)
+		Name() string
+	}
+
+	FileLoader struct {
+		Path       string
+		FileSystem FileSystem
+	}
+
+	FSLoader struct {
+		FS   fs.FS
+		Path string
+	}
+
+	HTTPLoader struct {
+		URL     string
+		Client  *http.Client
+		Retries int
+		Backoff time.Duration
+	}
+
+	StaleLoader struct {
+		Loader  ManifestLoader
+		OnStale func(err error)
+
+		mu       sync.Mutex
+		lastGood []byte
+	}
+
+	StaticLoader []byte
+)
+
+var defaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+func (loader FileLoader) Load(ctx context.Context) ([]byte, error) {
+	return fileSystemOrDefault(loader.FileSystem).ReadFile(loader.Path)
+}
+
+func (loader FileLoader) Name() string {
+	return loader.Path
+}
+
+func (loader FSLoader) Load(ctx context.Context) ([]byte, error) {
+	return fs.ReadFile(loader.FS, loader.Path)
+}
+
+func (loader FSLoader) Name() string {
+	return loader.Path
+}
+
+func (loader HTTPLoader) Load(ctx context.Context) ([]byte, error) {
+	backoff := loader.Backoff
+	if backoff <= 0 {
+		backoff = 100 * time.Millisecond
+	}
+
+	for attempt := 0; ; attempt++ {
+		content, retryable, err := loader.fetch(ctx)
+		if err == nil || !retryable || attempt >= loader.Retries {
+			return content, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff << attempt):
+		}
+	}
+}
+
+func (loader HTTPLoader) fetch(ctx context.Context) ([]byte, bool, error) {
+	client := loader.Client
+	if client == nil {
+		client = defaultHTTPClient
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, loader.URL, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, ctx.Err() == nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		retryable := response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests
+		return nil, retryable, fmt.Errorf("unexpected status %s", response.Status)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	return content, err != nil, err
+}
+
+func (loader HTTPLoader) Name() string {
+	return loader.URL
+}
+
+func NewStaleLoader(loader ManifestLoader) *StaleLoader {
+	return &StaleLoader{Loader: loader}
+}
+
+func (loader *StaleLoader) Load(ctx context.Context) ([]byte, error) {
+	content, err := loader.Loader.Load(ctx)
+
+	loader.mu.Lock()
+	defer loader.mu.Unlock()
+
+	if err != nil {
+		if loader.lastGood != nil {
+			if loader.OnStale != nil {
+				loader.OnStale(err)
+			}
+
+			return loader.lastGood, nil
+		}
+
+		return nil, err
+	}
+
+	loader.lastGood = content
+	return content, nil
+}
+
+func (loader *StaleLoader) Name() string {
+	return loader.Loader.Name()
+}
+
+func (loader StaticLoader) Load(ctx context.Context) ([]byte, error) {
+	return loader, nil
+}
+
+func (loader StaticLoader) Name() string {
+	return "static manifest"
+}
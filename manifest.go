@@ -0,0 +1,95 @@
+package goviteparser
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+type Decompressor func(r io.Reader) (io.Reader, error)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+func readManifest(ctx context.Context, loader ManifestLoader, decompressors map[string]Decompressor) ([]byte, error) {
+	content, err := loader.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, _, _ := strings.Cut(loader.Name(), "?")
+	extension := path.Ext(name)
+	if decompress, ok := decompressors[extension]; ok {
+		return decompressManifest(content, decompress)
+	}
+
+	if extension == ".gz" || bytes.HasPrefix(content, gzipMagic) {
+		return decompressManifest(content, gunzip)
+	}
+
+	if extension == ".br" {
+		return nil, fmt.Errorf("no decompressor registered for %s", extension)
+	}
+
+	return content, nil
+}
+
+func decompressManifest(content []byte, decompress Decompressor) ([]byte, error) {
+	reader, err := decompress(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("decompress manifest: %w", err)
+	}
+
+	content, err = io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("decompress manifest: %w", err)
+	}
+
+	return content, nil
+}
+
+func gunzip(r io.Reader) (io.Reader, error) {
+	return gzip.NewReader(r)
+}
+
+func hashManifest(manifest Manifest) string {
+	content, _ := json.Marshal(manifest)
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+func (vite *ViteManifestInfo) ManifestHash() string {
+	if vite.IsDev() {
+		return ""
+	}
+
+	return vite.manifestHash
+}
+
+func manifestHashMeta(hash string) string {
+	return fmt.Sprintf(`<meta name="vite-manifest-hash" content="%s" />`, hash)
+}
+
+func (vite *ViteManifestInfo) EntrypointHash(entry string) (string, error) {
+	entry = vite.entryKey(vite.Manifest, entry)
+	if _, ok := vite.Manifest[entry]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrEntryNotFound, entry)
+	}
+
+	hash := sha256.New()
+	for _, key := range staticImports(vite.Manifest, entry) {
+		chunk := vite.Manifest[key]
+		fmt.Fprintf(hash, "%s\x00%s\x00%s\n", key, chunk.File, chunk.Integrity)
+		for _, cssPath := range chunk.CSS {
+			fmt.Fprintf(hash, "%s\n", cssPath)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))[:12], nil
+}
@@ -0,0 +1,122 @@
+package goviteparser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+type MultiLoader struct {
+	Root       string
+	Dirs       []string
+	FileSystem FileSystem
+}
+
+var ErrManifestConflict = errors.New("conflicting manifest entries")
+
+func LoadManifests(dirs ...string) (Manifest, error) {
+	return MultiLoader{Dirs: dirs}.manifest()
+}
+
+func (loader MultiLoader) Load(ctx context.Context) ([]byte, error) {
+	manifest, err := loader.manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(manifest)
+}
+
+func (loader MultiLoader) Name() string {
+	return strings.Join(loader.Dirs, ",")
+}
+
+func (loader MultiLoader) manifest() (Manifest, error) {
+	merged := make(Manifest)
+	origins := make(map[string]string)
+	originals := make(map[string]EntryInfo)
+	for _, dir := range loader.Dirs {
+		manifest, err := loader.readDir(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		prefix := strings.Trim(filepath.ToSlash(dir), "/")
+		for key, entryInfo := range manifest {
+			if origin, ok := origins[key]; ok {
+				if !reflect.DeepEqual(originals[key], entryInfo) {
+					return nil, fmt.Errorf("%w: %s is defined by %s and %s", ErrManifestConflict, key, origin, dir)
+				}
+
+				continue
+			}
+
+			origins[key] = dir
+			originals[key] = entryInfo
+			merged[key] = prefixEntryFiles(entryInfo, prefix)
+		}
+	}
+
+	return merged, nil
+}
+
+func (loader MultiLoader) readDir(dir string) (Manifest, error) {
+	fileSystem := fileSystemOrDefault(loader.FileSystem)
+	base := filepath.Join(loader.Root, dir)
+
+	var errs []error
+	for _, candidate := range []string{filepath.Join(base, ".vite", "manifest.json"), filepath.Join(base, "manifest.json")} {
+		content, err := fileSystem.ReadFile(candidate)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		manifest := make(Manifest)
+		if err := json.Unmarshal(content, &manifest); err != nil {
+			return nil, fmt.Errorf("decode manifest %s: %w", candidate, err)
+		}
+
+		if err := (Config{}).applyIntegrity(manifest, content); err != nil {
+			return nil, fmt.Errorf("decode manifest %s: %w", candidate, err)
+		}
+
+		return manifest, nil
+	}
+
+	return nil, fmt.Errorf("read manifest in %s: %w", dir, errors.Join(errs...))
+}
+
+func prefixEntryFiles(entryInfo EntryInfo, prefix string) EntryInfo {
+	prefixFile := func(file string) string {
+		if prefix == "" || isAbsoluteURL(file) {
+			return file
+		}
+
+		return path.Join(prefix, file)
+	}
+
+	prefixFiles := func(files []string) []string {
+		if files == nil {
+			return nil
+		}
+
+		prefixed := make([]string, len(files))
+		for i, file := range files {
+			prefixed[i] = prefixFile(file)
+		}
+
+		return prefixed
+	}
+
+	entryInfo.File = prefixFile(entryInfo.File)
+	entryInfo.CSS = prefixFiles(entryInfo.CSS)
+	entryInfo.Assets = prefixFiles(entryInfo.Assets)
+	entryInfo.scripts = prefixFiles(entryInfo.scripts)
+	return entryInfo
+}
@@ -0,0 +1,27 @@
+package goviteparser
+
+import "strings"
+
+func minifyHTML(html string) string {
+	var builder strings.Builder
+	previous := ""
+	for _, line := range strings.Split(html, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if previous != "" && !joinsWithoutNewline(previous, line) {
+			builder.WriteByte('\n')
+		}
+
+		builder.WriteString(line)
+		previous = line
+	}
+
+	return builder.String()
+}
+
+func joinsWithoutNewline(previous string, next string) bool {
+	return strings.HasPrefix(next, "<") || strings.HasSuffix(previous, ">")
+}
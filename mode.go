@@ -0,0 +1,24 @@
+package goviteparser
+
+import (
+	"os"
+	"strings"
+)
+
+var modeEnvironmentVariables = []string{"APP_ENV", "GO_ENV"}
+
+func ModeFromEnv() Mode {
+	for _, name := range modeEnvironmentVariables {
+		value := strings.ToLower(strings.TrimSpace(os.Getenv(name)))
+		switch value {
+		case "":
+			continue
+		case "development", "dev", "local":
+			return ModeAuto
+		default:
+			return ModeProduction
+		}
+	}
+
+	return ModeAuto
+}
@@ -0,0 +1,133 @@
+package goviteparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func (entryInfo EntryInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		File           string   `json:"file"`
+		Name           string   `json:"name,omitempty"`
+		Src            string   `json:"src,omitempty"`
+		IsEntry        bool     `json:"isEntry,omitempty"`
+		IsDynamicEntry bool     `json:"isDynamicEntry,omitempty"`
+		Imports        []string `json:"imports,omitempty"`
+		DynamicImports []string `json:"dynamicImports,omitempty"`
+		CSS            []string `json:"css,omitempty"`
+		Assets         []string `json:"assets,omitempty"`
+		Integrity      string   `json:"integrity,omitempty"`
+	}{
+		File:           entryInfo.File,
+		Name:           entryInfo.Name,
+		Src:            entryInfo.Src,
+		IsEntry:        entryInfo.IsEntry,
+		IsDynamicEntry: entryInfo.IsDynamicEntry,
+		Imports:        entryInfo.Imports,
+		DynamicImports: entryInfo.DynamicImports,
+		CSS:            entryInfo.CSS,
+		Assets:         entryInfo.Assets,
+		Integrity:      entryInfo.Integrity,
+	})
+}
+
+func (manifest Manifest) Clone() Manifest {
+	clone := make(Manifest, len(manifest))
+	for key, entryInfo := range manifest {
+		entryInfo.CSS = cloneStrings(entryInfo.CSS)
+		entryInfo.Imports = cloneStrings(entryInfo.Imports)
+		entryInfo.DynamicImports = cloneStrings(entryInfo.DynamicImports)
+		entryInfo.Assets = cloneStrings(entryInfo.Assets)
+		entryInfo.scripts = cloneStrings(entryInfo.scripts)
+		clone[key] = entryInfo
+	}
+
+	return clone
+}
+
+func (manifest Manifest) RewriteFilePrefix(from string, to string) Manifest {
+	rewrite := func(file string) string {
+		if rest, ok := strings.CutPrefix(file, from); ok {
+			return to + rest
+		}
+
+		return file
+	}
+
+	rewritten := manifest.Clone()
+	for key, entryInfo := range rewritten {
+		entryInfo.File = rewrite(entryInfo.File)
+		entryInfo.CSS = mapStrings(entryInfo.CSS, rewrite)
+		entryInfo.Assets = mapStrings(entryInfo.Assets, rewrite)
+		entryInfo.scripts = mapStrings(entryInfo.scripts, rewrite)
+		rewritten[key] = entryInfo
+	}
+
+	return rewritten
+}
+
+func (manifest Manifest) Drop(keys ...string) Manifest {
+	remaining := manifest.Clone()
+	dropped := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		dropped[key] = true
+		delete(remaining, key)
+	}
+
+	keep := func(imports []string) []string {
+		kept := imports[:0:0]
+		for _, importPath := range imports {
+			if !dropped[importPath] {
+				kept = append(kept, importPath)
+			}
+		}
+
+		if len(kept) == 0 {
+			return nil
+		}
+
+		return kept
+	}
+
+	for key, entryInfo := range remaining {
+		entryInfo.Imports = keep(entryInfo.Imports)
+		entryInfo.DynamicImports = keep(entryInfo.DynamicImports)
+		remaining[key] = entryInfo
+	}
+
+	return remaining
+}
+
+func (manifest Manifest) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+
+	return nil
+}
+
+func cloneStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	return append([]string{}, values...)
+}
+
+func mapStrings(values []string, transform func(string) string) []string {
+	if values == nil {
+		return nil
+	}
+
+	mapped := make([]string, len(values))
+	for i, value := range values {
+		mapped[i] = transform(value)
+	}
+
+	return mapped
+}
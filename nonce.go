@@ -0,0 +1,83 @@
+package goviteparser
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+type NonceGenerator func() (string, error)
+
+const NormalizedNonce = "__NONCE__"
+
+var nonceAttributePattern = regexp.MustCompile(`nonce="[^"]*"`)
+
+var ErrInvalidNonceGenerator = errors.New("invalid nonce generator")
+
+func Base64NonceGenerator(size int, source io.Reader) NonceGenerator {
+	if size <= 0 {
+		return invalidNonceGenerator(fmt.Errorf("%w: size %d must be positive", ErrInvalidNonceGenerator, size))
+	}
+
+	return func() (string, error) {
+		buffer := make([]byte, size)
+		if _, err := io.ReadFull(source, buffer); err != nil {
+			return "", fmt.Errorf("generate nonce: %w", err)
+		}
+
+		return base64.StdEncoding.EncodeToString(buffer), nil
+	}
+}
+
+func AlphabetNonceGenerator(length int, alphabet string, source io.Reader) NonceGenerator {
+	if length <= 0 {
+		return invalidNonceGenerator(fmt.Errorf("%w: length %d must be positive", ErrInvalidNonceGenerator, length))
+	}
+
+	if len(alphabet) == 0 || len(alphabet) > 256 {
+		return invalidNonceGenerator(fmt.Errorf("%w: alphabet must have 1 to 256 bytes, got %d", ErrInvalidNonceGenerator, len(alphabet)))
+	}
+
+	limit := 256 - 256%len(alphabet)
+
+	return func() (string, error) {
+		nonce := make([]byte, 0, length)
+		buffer := make([]byte, length)
+		for len(nonce) < length {
+			if _, err := io.ReadFull(source, buffer); err != nil {
+				return "", fmt.Errorf("generate nonce: %w", err)
+			}
+
+			for _, b := range buffer {
+				if int(b) >= limit || len(nonce) == length {
+					continue
+				}
+
+				nonce = append(nonce, alphabet[int(b)%len(alphabet)])
+			}
+		}
+
+		return string(nonce), nil
+	}
+}
+
+func invalidNonceGenerator(err error) NonceGenerator {
+	return func() (string, error) {
+		return "", err
+	}
+}
+
+func (vite *ViteManifestInfo) GenerateNonce() (string, error) {
+	if vite.config.NonceGenerator != nil {
+		return vite.config.NonceGenerator()
+	}
+
+	return Base64NonceGenerator(16, rand.Reader)()
+}
+
+func NormalizeNonces(html string) string {
+	return nonceAttributePattern.ReplaceAllString(html, fmt.Sprintf(`nonce="%s"`, NormalizedNonce))
+}
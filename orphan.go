@@ -0,0 +1,142 @@
+package goviteparser
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var derivedExtensions = []string{".map", ".gz", ".br"}
+
+var ErrNoManifest = errors.New("no production manifest loaded")
+
+func (vite *ViteManifestInfo) FindOrphanAssets(buildDir string) ([]string, error) {
+	if vite.IsDev() || len(vite.Manifest) == 0 {
+		return nil, ErrNoManifest
+	}
+
+	referenced := vite.Manifest.referencedFiles()
+	manifestPaths := []string{vite.config.ManifestPath}
+	for name, environment := range vite.config.Environments {
+		manifestPaths = append(manifestPaths, environment.ManifestPath)
+		environmentDir := buildDir
+		if environment.ManifestPath != "" {
+			environmentDir = manifestBuildDir(environment.ManifestPath)
+		}
+
+		prefix, ok := relativeBuildPath(buildDir, environmentDir)
+		if !ok {
+			continue
+		}
+
+		for file := range vite.environments[name].referencedFiles() {
+			referenced[path.Join(prefix, file)] = true
+		}
+	}
+
+	for _, manifestPath := range manifestPaths {
+		if manifestPath == "" {
+			continue
+		}
+
+		if relativePath, ok := relativeBuildPath(buildDir, manifestPath); ok {
+			referenced[strings.TrimSuffix(strings.TrimSuffix(relativePath, ".gz"), ".br")] = true
+		}
+	}
+
+	orphans := []string{}
+	err := filepath.WalkDir(buildDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if entry.Name() == ".vite" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(buildDir, filePath)
+		if err != nil {
+			return err
+		}
+
+		relativePath = filepath.ToSlash(relativePath)
+		if relativePath == "manifest.json" || isReferencedAsset(relativePath, referenced) {
+			return nil
+		}
+
+		orphans = append(orphans, relativePath)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk build directory %s: %w", buildDir, err)
+	}
+
+	sort.Strings(orphans)
+	return orphans, nil
+}
+
+func relativeBuildPath(buildDir string, filePath string) (string, bool) {
+	relativePath, err := filepath.Rel(buildDir, filePath)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	return filepath.ToSlash(relativePath), true
+}
+
+func isReferencedAsset(relativePath string, referenced map[string]bool) bool {
+	for {
+		if referenced[relativePath] {
+			return true
+		}
+
+		trimmed := relativePath
+		for _, extension := range derivedExtensions {
+			trimmed = strings.TrimSuffix(trimmed, extension)
+		}
+
+		if trimmed == relativePath {
+			return false
+		}
+
+		relativePath = trimmed
+	}
+}
+
+func (manifest Manifest) referencedFiles() map[string]bool {
+	referenced := make(map[string]bool)
+	for _, entryInfo := range manifest {
+		referenced[entryInfo.File] = true
+		for _, cssPath := range entryInfo.CSS {
+			referenced[cssPath] = true
+		}
+
+		for _, assetPath := range entryInfo.Assets {
+			referenced[assetPath] = true
+		}
+
+		for _, scriptPath := range entryInfo.scripts {
+			referenced[scriptPath] = true
+		}
+	}
+
+	return referenced
+}
+
+func (manifest Manifest) ReferencedFiles() []string {
+	files := make([]string, 0, len(manifest))
+	for file := range manifest.referencedFiles() {
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,48 @@
+package goviteparser
+
+import "fmt"
+
+type (
+	Plugin interface {
+		Name() string
+		ConfigureVite(config *Config)
+		OnManifestLoaded(manifest Manifest) error
+		OnTagsGenerated(entrypoints []string, tags string) string
+	}
+
+	BasePlugin struct{}
+)
+
+func (BasePlugin) ConfigureVite(config *Config) {}
+
+func (BasePlugin) OnManifestLoaded(manifest Manifest) error {
+	return nil
+}
+
+func (BasePlugin) OnTagsGenerated(entrypoints []string, tags string) string {
+	return tags
+}
+
+func (config *Config) configurePlugins() {
+	for _, plugin := range config.Plugins {
+		plugin.ConfigureVite(config)
+	}
+}
+
+func (config Config) manifestLoaded(manifest Manifest) error {
+	for _, plugin := range config.Plugins {
+		if err := plugin.OnManifestLoaded(manifest); err != nil {
+			return fmt.Errorf("plugin %s: %w", plugin.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+func (config Config) tagsGenerated(entrypoints []string, tags string) string {
+	for _, plugin := range config.Plugins {
+		tags = plugin.OnTagsGenerated(entrypoints, tags)
+	}
+
+	return tags
+}
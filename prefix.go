@@ -0,0 +1,72 @@
+package goviteparser
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+func (vite *ViteManifestInfo) entryKey(manifest Manifest, entry string) string {
+	if len(vite.config.EntryPrefixes) == 0 {
+		return entry
+	}
+
+	exists := func(key string) bool {
+		if vite.IsDev() {
+			return vite.sourceExists(key)
+		}
+
+		_, ok := manifest[key]
+		return ok
+	}
+
+	if exists(entry) {
+		return entry
+	}
+
+	for _, prefix := range vite.config.EntryPrefixes {
+		if key := joinEntryPrefix(prefix, entry); exists(key) {
+			return key
+		}
+	}
+
+	return entry
+}
+
+func (vite *ViteManifestInfo) entryKeys(manifest Manifest, entrypoints []string) []string {
+	keys := make([]string, len(entrypoints))
+	for i, entry := range entrypoints {
+		keys[i] = vite.entryKey(manifest, entry)
+	}
+
+	return keys
+}
+
+func (config Config) isEntrypoint(key string, entrypoints []string) bool {
+	for _, entry := range entrypoints {
+		if key == entry {
+			return true
+		}
+
+		for _, prefix := range config.EntryPrefixes {
+			if key == joinEntryPrefix(prefix, entry) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (vite *ViteManifestInfo) sourceExists(source string) bool {
+	info, err := vite.config.fileSystem().Stat(filepath.Join(vite.config.Root, filepath.FromSlash(source)))
+	return err == nil && !info.IsDir()
+}
+
+func joinEntryPrefix(prefix string, entry string) string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return entry
+	}
+
+	return prefix + "/" + entry
+}
@@ -0,0 +1,174 @@
+package goviteparser
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+type (
+	PreloadedAsset struct {
+		URL           string
+		Rel           string
+		As            string
+		Type          string
+		Chunk         string
+		Integrity     string
+		FetchPriority string
+		CrossOrigin   string
+	}
+
+	PreloadType struct {
+		As   string
+		Type string
+	}
+)
+
+var DefaultPreloadTypes = map[string]PreloadType{
+	".js":    {As: "script"},
+	".mjs":   {As: "script"},
+	".css":   {As: "style"},
+	".woff2": {As: "font", Type: "font/woff2"},
+	".woff":  {As: "font", Type: "font/woff"},
+	".ttf":   {As: "font", Type: "font/ttf"},
+	".otf":   {As: "font", Type: "font/otf"},
+	".jpg":   {As: "image", Type: "image/jpeg"},
+	".jpeg":  {As: "image", Type: "image/jpeg"},
+	".png":   {As: "image", Type: "image/png"},
+	".webp":  {As: "image", Type: "image/webp"},
+	".avif":  {As: "image", Type: "image/avif"},
+	".svg":   {As: "image", Type: "image/svg+xml"},
+	".mp4":   {As: "video", Type: "video/mp4"},
+	".webm":  {As: "video", Type: "video/webm"},
+}
+
+func (vite *ViteManifestInfo) PreloadedAssets(ctx context.Context, entrypoints []string, opts ...InvokeOption) ([]PreloadedAsset, error) {
+	if vite.IsDev() {
+		return []PreloadedAsset{}, nil
+	}
+
+	var tags []*Tag
+	opts = append(opts, func(options *invokeOptions) {
+		options.collect = func(tag *Tag) {
+			tags = append(tags, tag)
+		}
+	})
+
+	if _, err := vite.invoke(ctx, entrypoints, false, opts...); err != nil {
+		return nil, err
+	}
+
+	rank := func(tag *Tag) int {
+		switch {
+		case tag.Kind == TagStyle:
+			return 0
+		case tag.Kind == TagFont:
+			return 1
+		case tag.Kind == TagPreload && vite.config.isEntrypoint(tag.Chunk, entrypoints):
+			return 2
+		case tag.Kind == TagPreload:
+			return 3
+		default:
+			return 4
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return rank(tags[i]) < rank(tags[j])
+	})
+
+	assets := []PreloadedAsset{}
+	for _, tag := range tags {
+		asset := PreloadedAsset{
+			URL:           tag.URL,
+			Chunk:         tag.Chunk,
+			Integrity:     tag.attributeString("integrity"),
+			FetchPriority: tag.attributeString("fetchpriority"),
+			CrossOrigin:   tag.crossOrigin(),
+		}
+
+		preloadType, ok := vite.config.preloadType(tag.URL)
+		switch tag.Kind {
+		case TagPreload:
+			if !ok {
+				preloadType = PreloadType{As: "script"}
+			}
+
+			asset.Rel = "preload"
+			if preloadType.As == "script" {
+				asset.Rel = "modulepreload"
+			}
+		case TagStyle:
+			if !ok {
+				preloadType = PreloadType{As: "style"}
+			}
+
+			asset.Rel = "preload"
+		case TagFont:
+			if !ok {
+				preloadType = PreloadType{As: "font"}
+			}
+
+			asset.Rel = "preload"
+		default:
+			continue
+		}
+
+		asset.As = preloadType.As
+		asset.Type = preloadType.Type
+		if asset.As == "font" && asset.CrossOrigin == "" {
+			asset.CrossOrigin = "anonymous"
+		}
+
+		assets = append(assets, asset)
+	}
+
+	return assets, nil
+}
+
+func LinkHeader(assets []PreloadedAsset) string {
+	links := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		link := fmt.Sprintf("<%s>; rel=%s; as=%s", asset.URL, asset.Rel, asset.As)
+		if asset.Type != "" {
+			link += "; type=" + asset.Type
+		}
+
+		if asset.CrossOrigin != "" {
+			link += "; crossorigin=" + asset.CrossOrigin
+		}
+
+		if asset.Integrity != "" {
+			link += fmt.Sprintf(`; integrity="%s"`, asset.Integrity)
+		}
+
+		if asset.FetchPriority != "" {
+			link += "; fetchpriority=" + asset.FetchPriority
+		}
+
+		links = append(links, link)
+	}
+
+	return strings.Join(links, ", ")
+}
+
+func (config Config) preloadType(file string) (PreloadType, bool) {
+	return preloadTypeFor(config.PreloadTypes, file)
+}
+
+func preloadTypeFor(types map[string]PreloadType, file string) (PreloadType, bool) {
+	if fileURL, err := url.Parse(file); err == nil {
+		file = fileURL.Path
+	}
+
+	extension := strings.ToLower(path.Ext(file))
+	if preloadType, ok := types[extension]; ok {
+		return preloadType, preloadType.As != ""
+	}
+
+	preloadType, ok := DefaultPreloadTypes[extension]
+	return preloadType, ok
+}
@@ -0,0 +1,24 @@
+package goviteparser
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+type profileContextKey struct{}
+
+const profileEnvironmentVariable = "VITE_PROFILE"
+
+func ProfileFromEnv() string {
+	return strings.TrimSpace(os.Getenv(profileEnvironmentVariable))
+}
+
+func WithProfileContext(ctx context.Context, profile string) context.Context {
+	return context.WithValue(ctx, profileContextKey{}, profile)
+}
+
+func profileFromContext(ctx context.Context) string {
+	profile, _ := ctx.Value(profileContextKey{}).(string)
+	return profile
+}
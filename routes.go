@@ -0,0 +1,64 @@
+package goviteparser
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strings"
+)
+
+type (
+	Route struct {
+		Pattern     string
+		Entrypoints []string
+	}
+
+	RouteMap []Route
+
+	tagsContextKey struct{}
+)
+
+func (routes RouteMap) Match(requestPath string) ([]string, bool) {
+	for _, route := range routes {
+		if matchRoute(route.Pattern, requestPath) {
+			return route.Entrypoints, true
+		}
+	}
+
+	return nil, false
+}
+
+func (vite *ViteManifestInfo) RouteMiddleware(routes RouteMap) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			entrypoints, ok := routes.Match(r.URL.Path)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			tags, err := vite.InvokeCtx(r.Context(), entrypoints)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), tagsContextKey{}, tags)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func TagsFromContext(ctx context.Context) string {
+	tags, _ := ctx.Value(tagsContextKey{}).(string)
+	return tags
+}
+
+func matchRoute(pattern string, requestPath string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return requestPath == prefix || strings.HasPrefix(requestPath, prefix+"/")
+	}
+
+	matched, err := path.Match(pattern, requestPath)
+	return err == nil && matched
+}
@@ -0,0 +1,106 @@
+package goviteparser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+type SchemaError struct {
+	Key     string
+	Field   string
+	Message string
+}
+
+var (
+	stringFields      = []string{"src", "name"}
+	booleanFields     = []string{"isEntry", "isDynamicEntry"}
+	stringArrayFields = []string{"imports", "dynamicImports", "css", "assets"}
+)
+
+func (err *SchemaError) Error() string {
+	if err.Key == "" {
+		return fmt.Sprintf("manifest: %s", err.Message)
+	}
+
+	if err.Field == "" {
+		return fmt.Sprintf("manifest key %q: %s", err.Key, err.Message)
+	}
+
+	return fmt.Sprintf("manifest key %q: field %q: %s", err.Key, err.Field, err.Message)
+}
+
+func (vite *ViteManifestInfo) ValidateSchema() error {
+	loader := vite.config.manifestLoader()
+	content, err := readManifest(context.Background(), loader, vite.config.Decompressors)
+	if err != nil {
+		return fmt.Errorf("read manifest %s: %w", loader.Name(), err)
+	}
+
+	return validateSchema(content)
+}
+
+func validateSchema(content []byte) error {
+	var chunks map[string]json.RawMessage
+	if err := json.Unmarshal(content, &chunks); err != nil {
+		return &SchemaError{Message: fmt.Sprintf("expected an object of chunks: %v", err)}
+	}
+
+	keys := make([]string, 0, len(chunks))
+	for key := range chunks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		var fields map[string]any
+		if err := json.Unmarshal(chunks[key], &fields); err != nil || fields == nil {
+			errs = append(errs, &SchemaError{Key: key, Message: "chunk must be an object"})
+			continue
+		}
+
+		if file, ok := fields["file"].(string); !ok || file == "" {
+			errs = append(errs, &SchemaError{Key: key, Field: "file", Message: "required non-empty string"})
+		}
+
+		for _, field := range stringFields {
+			if value, ok := fields[field]; ok {
+				if _, ok := value.(string); !ok {
+					errs = append(errs, &SchemaError{Key: key, Field: field, Message: "must be a string"})
+				}
+			}
+		}
+
+		for _, field := range booleanFields {
+			if value, ok := fields[field]; ok {
+				if _, ok := value.(bool); !ok {
+					errs = append(errs, &SchemaError{Key: key, Field: field, Message: "must be a boolean"})
+				}
+			}
+		}
+
+		for _, field := range stringArrayFields {
+			value, ok := fields[field]
+			if !ok {
+				continue
+			}
+
+			items, ok := value.([]any)
+			if !ok {
+				errs = append(errs, &SchemaError{Key: key, Field: field, Message: "must be an array of strings"})
+				continue
+			}
+
+			for i, item := range items {
+				if _, ok := item.(string); !ok {
+					errs = append(errs, &SchemaError{Key: key, Field: field, Message: fmt.Sprintf("item %d must be a string", i)})
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
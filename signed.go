@@ -0,0 +1,75 @@
+package goviteparser
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid asset url signature")
+	ErrSignatureExpired = errors.New("asset url signature expired")
+)
+
+type URLSigner struct {
+	Secret []byte
+	TTL    time.Duration
+	Clock  Clock
+}
+
+func SignedURLResolver(secret []byte, ttl time.Duration) AssetPathResolver {
+	return URLSigner{Secret: secret, TTL: ttl}.Resolver()
+}
+
+func VerifySignedURL(secret []byte, assetURL *url.URL) error {
+	return URLSigner{Secret: secret}.Verify(assetURL)
+}
+
+func (signer URLSigner) Resolver() AssetPathResolver {
+	return cachedResolver(signer.Clock, signer.TTL, func(rawURL string, now time.Time) string {
+		assetURL, err := url.Parse(rawURL)
+		if err != nil {
+			return rawURL
+		}
+
+		expires := strconv.FormatInt(now.Add(signer.TTL).Unix(), 10)
+		query := assetURL.Query()
+		query.Set("expires", expires)
+		query.Set("signature", signAssetPath(signer.Secret, assetURL.EscapedPath(), expires))
+		assetURL.RawQuery = query.Encode()
+
+		return assetURL.String()
+	})
+}
+
+func (signer URLSigner) Verify(assetURL *url.URL) error {
+	query := assetURL.Query()
+	expires := query.Get("expires")
+	signature := query.Get("signature")
+
+	expected := signAssetPath(signer.Secret, assetURL.EscapedPath(), expires)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if clockOrDefault(signer.Clock).Now().Unix() > unix {
+		return ErrSignatureExpired
+	}
+
+	return nil
+}
+
+func signAssetPath(secret []byte, assetPath string, expires string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(assetPath + "\n" + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
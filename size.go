@@ -0,0 +1,70 @@
+package goviteparser
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+)
+
+type (
+	SizeReport struct {
+		Files  []FileSize
+		Size   int64
+		Gzip   int64
+		Brotli int64
+	}
+
+	FileSize struct {
+		Chunk  string
+		File   string
+		Size   int64
+		Gzip   int64
+		Brotli int64
+	}
+)
+
+func (vite *ViteManifestInfo) SizeReport(entrypoints ...string) (SizeReport, error) {
+	files, err := vite.pageFiles(entrypoints)
+	if err != nil {
+		return SizeReport{}, err
+	}
+
+	report := SizeReport{Files: []FileSize{}}
+	for _, file := range files {
+		filePath := filepath.Join(vite.config.buildPath(), filepath.FromSlash(file.file))
+		info, err := vite.config.fileSystem().Stat(filePath)
+		if err != nil {
+			return SizeReport{}, fmt.Errorf("stat asset %s: %w", file.file, err)
+		}
+
+		size := FileSize{Chunk: file.chunk, File: file.file, Size: info.Size()}
+		if size.Gzip, err = vite.config.siblingSize(filePath + ".gz"); err != nil {
+			return SizeReport{}, err
+		}
+
+		if size.Brotli, err = vite.config.siblingSize(filePath + ".br"); err != nil {
+			return SizeReport{}, err
+		}
+
+		report.Files = append(report.Files, size)
+		report.Size += size.Size
+		report.Gzip += size.Gzip
+		report.Brotli += size.Brotli
+	}
+
+	return report, nil
+}
+
+func (config Config) siblingSize(filePath string) (int64, error) {
+	info, err := config.fileSystem().Stat(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("stat compressed asset %s: %w", filePath, err)
+	}
+
+	return info.Size(), nil
+}
@@ -0,0 +1,37 @@
+package goviteparser
+
+import "strings"
+
+type (
+	SnippetPosition int
+
+	Snippet struct {
+		Position SnippetPosition
+		HTML     string
+	}
+)
+
+const (
+	SnippetBeforePreload SnippetPosition = iota
+	SnippetAfterPreload
+	SnippetBeforeScripts
+	SnippetAfterAll
+)
+
+const SnippetNonce = "__VITE_NONCE__"
+
+func (options *invokeOptions) renderWithSnippets(tags chunkTags, snippets []Snippet) string {
+	if len(snippets) == 0 {
+		return tags.render()
+	}
+
+	rendered := make(map[SnippetPosition]string)
+	nonce := options.nonceFor(TagScript)
+	for _, snippet := range snippets {
+		rendered[snippet.Position] += strings.ReplaceAll(snippet.HTML, SnippetNonce, nonce)
+	}
+
+	return rendered[SnippetBeforePreload] + tags.style + tags.fontPreload + tags.entryPreload + tags.sharedPreload +
+		rendered[SnippetAfterPreload] + rendered[SnippetBeforeScripts] + tags.script +
+		rendered[SnippetAfterAll]
+}
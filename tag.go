@@ -0,0 +1,133 @@
+package goviteparser
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+)
+
+type (
+	Tag struct {
+		Kind       TagKind
+		Chunk      string
+		URL        string
+		Classic    bool
+		Attributes []Attribute
+	}
+
+	Attribute struct {
+		Name  string
+		Value any
+	}
+)
+
+func (tag *Tag) Get(name string) (any, bool) {
+	for _, attribute := range tag.Attributes {
+		if attribute.Name == name {
+			return attribute.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+func (tag *Tag) Set(name string, value any) {
+	for i, attribute := range tag.Attributes {
+		if attribute.Name == name {
+			tag.Attributes[i].Value = value
+			return
+		}
+	}
+
+	tag.Attributes = append(tag.Attributes, Attribute{Name: name, Value: value})
+}
+
+func (tag *Tag) Remove(name string) {
+	attributes := tag.Attributes[:0]
+	for _, attribute := range tag.Attributes {
+		if attribute.Name != name {
+			attributes = append(attributes, attribute)
+		}
+	}
+
+	tag.Attributes = attributes
+}
+
+func (tag *Tag) Render() string {
+	attributes := renderAttributes(tag.Attributes)
+	switch tag.Kind {
+	case TagPreload:
+		return createPreloadTag(tag.URL, attributes)
+	case TagStyle:
+		return createStyleTag(tag.URL, attributes)
+	case TagFont:
+		return createFontPreloadTag(tag.URL, attributes)
+	default:
+		if tag.Classic {
+			return createClassicScriptTag(tag.URL, attributes)
+		}
+
+		return createScriptTag(tag.URL, attributes)
+	}
+}
+
+func (tag *Tag) attributeString(name string) string {
+	value, ok := tag.Get(name)
+	if !ok {
+		return ""
+	}
+
+	return attributeValue(value)
+}
+
+func (tag *Tag) crossOrigin() string {
+	if value, ok := tag.Get("crossorigin"); ok && value == true {
+		return "anonymous"
+	}
+
+	return tag.attributeString("crossorigin")
+}
+
+func renderAttributes(attributes []Attribute) string {
+	rendered := ""
+	for _, attribute := range attributes {
+		switch value := attribute.Value.(type) {
+		case nil:
+			continue
+		case bool:
+			if value {
+				rendered += " " + attribute.Name
+			}
+		default:
+			rendered += fmt.Sprintf(` %s="%s"`, attribute.Name, html.EscapeString(attributeValue(value)))
+		}
+	}
+
+	return rendered
+}
+
+func attributeValue(value any) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case int:
+		return strconv.Itoa(value)
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case uint:
+		return strconv.FormatUint(uint64(value), 10)
+	case uint64:
+		return strconv.FormatUint(value, 10)
+	case float32:
+		return strconv.FormatFloat(float64(value), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case []string:
+		return strings.Join(value, " ")
+	case fmt.Stringer:
+		return value.String()
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
@@ -1,24 +1,91 @@
 package goviteparser
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
-	"os"
 	"path"
+	"path/filepath"
+	"strings"
 )
 
 type (
+	Mode int
+
 	Config struct {
+		Mode          Mode
+		OutDir        string
+		ManifestPath  string
+		HotFilePath   string
+		BuildPath     string
+		Root          string
+		EntryPrefixes []string
+		SourceMaps    SourceMapMode
+		FileSystem    FileSystem
+
+		Decompressors map[string]Decompressor
+		Unmarshal     func(data []byte, v any) error
+		Adapter       ManifestAdapter
+		Loader        ManifestLoader
+		IntegrityKeys []string
+
+		AssetPathResolver AssetPathResolver
+		ChunkURLRewriter  func(chunk string, file string) string
+		CDNFallback       string
+		CrossOrigin       string
+		ResourceHints     []ResourceHint
+		PreloadTypes      map[string]PreloadType
+		PreloadFonts      bool
+		Snippets          []Snippet
+
+		Debug            bool
+		BeginMarker      string
+		EndMarker        string
+		OnWarning        func(err error)
+		MinifyHTML       bool
+		ManifestHashMeta bool
+		OnAssetResolved  func(entry, file, url string)
+		OnManifestFiles  func(files []string) error
+		BeforeTag        func(tag *Tag) bool
+		AfterTag         func(tag Tag, html string) string
+
+		NonceGenerator    NonceGenerator
+		AutoReactRefresh  bool
+		ViteClient        string
+		DevBase           string
+		DevCrossOrigin    string
+		DisableViteClient bool
+		DirectDevCSS      bool
+		NoInlineScripts   bool
+		ScriptPath        string
+
+		Environments map[string]Environment
+		Profile      string
+		Plugins      []Plugin
+	}
+
+	Environment struct {
 		OutDir       string
 		ManifestPath string
-		HotFilePath  string
+		Loader       ManifestLoader
 	}
 
 	EntryInfo struct {
-		File    string   `json:"file"`
-		CSS     []string `json:"css"`
-		Imports []string `json:"imports"`
+		File           string   `json:"file"`
+		Src            string   `json:"src"`
+		Name           string   `json:"name"`
+		IsEntry        bool     `json:"isEntry"`
+		IsDynamicEntry bool     `json:"isDynamicEntry"`
+		CSS            []string `json:"css"`
+		Imports        []string `json:"imports"`
+		DynamicImports []string `json:"dynamicImports"`
+		Assets         []string `json:"assets"`
+		Integrity      string   `json:"-"`
+
+		scripts []string
+		classic bool
 	}
 
 	HTMLTags struct {
@@ -37,9 +104,40 @@ type (
 		Client       string
 		ClientTag    string
 		ReactRefresh string
+
+		config                 Config
+		environments           map[string]Manifest
+		assets                 map[string]string
+		integrities            map[string]string
+		defaults               []InvokeOption
+		environmentIntegrities map[string]map[string]string
+		manifestHash           string
+		environmentHashes      map[string]string
 	}
+
+	chunkTags struct {
+		fontPreload   string
+		entryPreload  string
+		sharedPreload string
+		style         string
+		script        string
+	}
+
+	ResolvedChunk struct {
+		Key  string
+		File string
+		URL  string
+	}
+)
+
+const (
+	ModeAuto Mode = iota
+	ModeProduction
+	ModeDevelopment
 )
 
+var ErrHotFileRequired = errors.New("hot file required in development mode")
+
 var (
 	scriptExtensions = []string{
 		".js",
@@ -65,36 +163,84 @@ var (
 )
 
 func Parse(config Config) ViteManifestInfo {
-	var err error
+	vite, err := Load(config)
+	if err != nil {
+		config.warn(err)
+	}
+
+	return vite
+}
+
+func Load(config Config) (ViteManifestInfo, error) {
+	return LoadCtx(context.Background(), config)
+}
+
+func LoadCtx(ctx context.Context, config Config) (ViteManifestInfo, error) {
+	var errs []error
+
+	config.configurePlugins()
 
 	origin := ""
-	hotFilePath := path.Clean(config.HotFilePath)
-	_, err = os.Stat(hotFilePath)
-	if err == nil {
-		content, err := os.ReadFile(hotFilePath)
-		if err == nil {
-			origin = string(content)
+	hotFilePath := filepath.Clean(config.HotFilePath)
+	info, err := config.fileSystem().Stat(hotFilePath)
+	if config.Mode != ModeProduction && err == nil && !info.IsDir() {
+		content, err := config.fileSystem().ReadFile(hotFilePath)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("read hot file %s: %w", hotFilePath, err))
+		} else {
+			origin = strings.TrimSpace(string(content))
 		}
 	}
 
+	if config.Mode == ModeDevelopment && origin == "" {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrHotFileRequired, hotFilePath))
+	}
+
 	manifest := make(Manifest)
-	if origin == "" {
-		manifestPath := path.Join(config.ManifestPath)
-		content, err := os.ReadFile(manifestPath)
-		if err == nil {
-			_ = json.Unmarshal(content, &manifest)
+	environments := make(map[string]Manifest)
+	if origin == "" && config.Mode != ModeDevelopment {
+		manifest, err = config.loadManifest(ctx, config.manifestLoader())
+		if err != nil {
+			errs = append(errs, err)
+		} else if err := config.manifestLoaded(manifest); err != nil {
+			errs = append(errs, err)
+		} else if config.OnManifestFiles != nil {
+			if err := config.OnManifestFiles(manifest.ReferencedFiles()); err != nil {
+				errs = append(errs, fmt.Errorf("manifest files hook: %w", err))
+			}
+		}
+
+		for name, environment := range config.Environments {
+			environments[name], err = config.loadManifest(ctx, environment.manifestLoader(config.FileSystem))
+			if err == nil {
+				err = config.manifestLoaded(environments[name])
+			}
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf("environment %s: %w", name, err))
+			}
 		}
 	}
 
 	client := ""
 	clientTag := ""
-	if origin != "" {
-		client, err = url.JoinPath(origin, "/@vite/client")
-		if err == nil {
-			clientTag = createScriptTag(client)
+	if origin != "" && !config.DisableViteClient {
+		client, err = config.viteClientURL(config.devOrigin(origin))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("resolve vite client url: %w", err))
+		} else {
+			clientTag = createScriptTag(client, renderAttributes([]Attribute{{Name: "crossorigin", Value: crossOriginValue(config.DevCrossOrigin)}}))
 		}
 	}
 
+	integrities := indexIntegrities(manifest)
+	environmentIntegrities := make(map[string]map[string]string, len(environments))
+	environmentHashes := make(map[string]string, len(environments))
+	for name, environment := range environments {
+		environmentIntegrities[name] = indexIntegrities(environment)
+		environmentHashes[name] = hashManifest(environment)
+	}
+
 	manifestTags := make(ManifestTags)
 
 	prefix := origin
@@ -102,46 +248,142 @@ func Parse(config Config) ViteManifestInfo {
 		prefix = config.OutDir
 	}
 
-	for entry, entryInfo := range manifest {
-		manifestTags[entry] = resolveTagEntry(manifest, entryInfo, prefix)
+	for entry := range manifest {
+		options := config.urlOptions()
+		options.manifest = manifest
+		options.integrities = integrities
+		options.crossOrigin = config.CrossOrigin
+		options.rendered = make(map[string]string)
+		options.preloadTypes = config.PreloadTypes
+		options.preloadFonts = config.PreloadFonts
+		manifestTags[entry] = resolveTagEntry(manifest, entry, prefix, &options).htmlTags()
 	}
 
+	assets, ambiguous := indexAssets(manifest)
+	for _, name := range ambiguous {
+		config.warn(fmt.Errorf("%w: %s", ErrAmbiguousAsset, name))
+	}
+
+	reactRefresh := config.reactRefreshTag(config.devOrigin(origin), "")
+
 	return ViteManifestInfo{
-		Origin:       origin,
-		Manifest:     manifest,
-		ManifestTags: manifestTags,
-		Client:       client,
-		ClientTag:    clientTag,
-		ReactRefresh: createReactRefreshTag(origin),
+		Origin:                 origin,
+		Manifest:               manifest,
+		ManifestTags:           manifestTags,
+		Client:                 client,
+		ClientTag:              clientTag,
+		ReactRefresh:           reactRefresh,
+		config:                 config,
+		environments:           environments,
+		assets:                 assets,
+		integrities:            integrities,
+		environmentIntegrities: environmentIntegrities,
+		manifestHash:           hashManifest(manifest),
+		environmentHashes:      environmentHashes,
+	}, errors.Join(errs...)
+}
+
+func (config Config) viteClientURL(origin string) (string, error) {
+	if config.ViteClient == "" {
+		return url.JoinPath(origin, "/@vite/client")
+	}
+
+	if clientURL, err := url.Parse(config.ViteClient); err == nil && clientURL.IsAbs() {
+		return config.ViteClient, nil
 	}
+
+	return url.JoinPath(origin, config.ViteClient)
 }
 
-func (tags *HTMLTags) Render() string {
-	return tags.Preload + tags.CSS + tags.JS
+func (config Config) warn(err error) {
+	if config.OnWarning != nil {
+		config.OnWarning(err)
+	}
 }
 
-func (vite *ViteManifestInfo) EntryDevTag(input string) (string, error) {
-	urlPath, err := url.JoinPath(vite.Origin, input)
+func (config Config) manifestLoader() ManifestLoader {
+	if config.Loader != nil {
+		return config.Loader
+	}
+
+	return FileLoader{Path: filepath.Clean(config.ManifestPath), FileSystem: config.FileSystem}
+}
+
+func (environment Environment) manifestLoader(fileSystem FileSystem) ManifestLoader {
+	if environment.Loader != nil {
+		return environment.Loader
+	}
+
+	return FileLoader{Path: filepath.Clean(environment.ManifestPath), FileSystem: fileSystem}
+}
+
+func (config Config) loadManifest(ctx context.Context, loader ManifestLoader) (Manifest, error) {
+	manifest := make(Manifest)
+	manifestPath := loader.Name()
+	content, err := readManifest(ctx, loader, config.Decompressors)
 	if err != nil {
-		return "", err
+		return manifest, fmt.Errorf("read manifest %s: %w", manifestPath, err)
 	}
 
-	extension := path.Ext(input)
-	if inArray(extension, scriptExtensions) {
-		return createScriptTag(urlPath), nil
-	} else if inArray(extension, styleExtensions) {
-		return createStyleTag(urlPath), nil
+	if config.Adapter != nil {
+		adapted, err := config.Adapter.Adapt(content)
+		if err != nil {
+			return manifest, fmt.Errorf("adapt manifest %s: %w", manifestPath, err)
+		}
+
+		return adapted, nil
+	}
+
+	if err := config.unmarshal(content, &manifest); err != nil {
+		return manifest, fmt.Errorf("decode manifest %s: %w", manifestPath, err)
 	}
 
-	return "", nil
+	if err := config.applyIntegrity(manifest, content); err != nil {
+		return manifest, fmt.Errorf("decode manifest %s: %w", manifestPath, err)
+	}
+
+	return manifest, nil
+}
+
+func (config Config) unmarshal(data []byte, v any) error {
+	if config.Unmarshal != nil {
+		return config.Unmarshal(data, v)
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func (tags *HTMLTags) Render() string {
+	return tags.CSS + tags.Preload + tags.JS
+}
+
+func (vite *ViteManifestInfo) EntryDevTag(input string) (string, error) {
+	return vite.entryDevTag(input, &invokeOptions{})
 }
 
 func (vite *ViteManifestInfo) IsDev() bool {
 	return vite.Origin != ""
 }
 
+func (vite *ViteManifestInfo) IsProduction() bool {
+	return !vite.IsDev()
+}
+
+func (vite *ViteManifestInfo) Mode() Mode {
+	if vite.IsDev() {
+		return ModeDevelopment
+	}
+
+	return ModeProduction
+}
+
+func (vite *ViteManifestInfo) ChunkFor(entry string) (EntryInfo, bool) {
+	entryInfo, ok := vite.Manifest[vite.entryKey(vite.Manifest, entry)]
+	return entryInfo, ok
+}
+
 func (vite *ViteManifestInfo) RenderTags(entry string) string {
-	tags, ok := vite.ManifestTags[entry]
+	tags, ok := vite.ManifestTags[vite.entryKey(vite.Manifest, entry)]
 	if !ok {
 		return ""
 	}
@@ -154,25 +396,17 @@ func (vite *ViteManifestInfo) RenderDevTags(entry string) (string, error) {
 }
 
 func (vite *ViteManifestInfo) RenderEntriesTag(entries ...string) string {
-	tags := ""
-	for _, entry := range entries {
-		tags += vite.RenderTags(entry)
-	}
+	tags, _ := vite.invoke(context.Background(), entries, false)
+	return tags
+}
 
+func (vite *ViteManifestInfo) RenderEntriesTagFor(buildDirectory string, entries ...string) string {
+	tags, _ := vite.invoke(context.Background(), entries, false, WithBuildDirectory(buildDirectory))
 	return tags
 }
 
 func (vite *ViteManifestInfo) RenderDevEntriesTag(entries ...string) string {
-	tags := ""
-	for _, entry := range entries {
-		tag, err := vite.RenderDevTags(entry)
-		if err != nil {
-			continue
-		}
-
-		tags += tag
-	}
-
+	tags, _ := vite.invoke(context.Background(), entries, true)
 	return tags
 }
 
@@ -184,44 +418,141 @@ func (vite *ViteManifestInfo) RenderReactRefreshTag() string {
 	return vite.ReactRefresh
 }
 
-func resolveTagEntry(manifest Manifest, entryInfo EntryInfo, prefix string) HTMLTags {
-	preload := ""
+func resolveTagEntry(manifest Manifest, entry string, prefix string, options *invokeOptions) chunkTags {
+	entryInfo := manifest[entry]
+	if inArray(path.Ext(entryInfo.File), styleExtensions) {
+		return resolveStyleEntry(manifest, entry, prefix, options)
+	}
+
+	fontPreload := ""
+	entryPreload := ""
+	sharedPreload := ""
 	style := ""
 	script := ""
 
-	preload += createPreloadTag(prefix + entryInfo.File)
+	if !entryInfo.classic {
+		entryPreload += options.preloadTag(entry, prefix, entryInfo.File, options.entryPriority)
+	}
 	for _, cssPath := range entryInfo.CSS {
-		style += createStyleTag(prefix + cssPath)
+		style += options.fileTag(TagStyle, entry, prefix, cssPath)
 	}
 
-	for _, importPath := range entryInfo.Imports {
-		importEntryInfo, ok := manifest[importPath]
-		if ok && importEntryInfo.File != "" {
-			preload += createPreloadTag(prefix + importEntryInfo.File)
-		}
+	fontPreload += options.fontTags(entry, prefix, entryInfo.Assets)
+
+	discovered := make(map[string]bool)
+	depths := importDepths(manifest, entryInfo.Imports)
+
+	var walk func(imports []string)
+	walk = func(imports []string) {
+		for _, importPath := range imports {
+			importEntryInfo, ok := manifest[importPath]
+			if !ok || discovered[importPath] {
+				continue
+			}
+
+			discovered[importPath] = true
+			if importEntryInfo.File != "" && (options.preloadDepth <= 0 || depths[importPath] <= options.preloadDepth) {
+				sharedPreload += options.preloadTag(importPath, prefix, importEntryInfo.File, options.sharedPriority)
+			} else if importEntryInfo.File != "" {
+				options.explainSkip(importPath, importEntryInfo.File, fmt.Sprintf("modulepreload beyond preload depth %d", options.preloadDepth))
+			}
 
-		if ok && len(importEntryInfo.CSS) > 0 {
 			for _, cssPath := range importEntryInfo.CSS {
-				style += createStyleTag(prefix + cssPath)
+				style += options.fileTag(TagStyle, importPath, prefix, cssPath)
 			}
+
+			fontPreload += options.fontTags(importPath, prefix, importEntryInfo.Assets)
+			walk(importEntryInfo.Imports)
 		}
 	}
 
+	walk(entryInfo.Imports)
+
+	for _, scriptPath := range entryInfo.scripts {
+		script += options.scriptTag(entry, prefix, scriptPath, entryInfo.classic)
+	}
+
 	file := entryInfo.File
 	extension := path.Ext(file)
 	if inArray(extension, scriptExtensions) {
-		script += createScriptTag(prefix + file)
+		script += options.scriptTag(entry, prefix, file, entryInfo.classic)
 	} else if inArray(extension, styleExtensions) {
-		style += createStyleTag(prefix + file)
+		style += options.fileTag(TagStyle, entry, prefix, file)
+	}
+
+	return chunkTags{
+		fontPreload:   fontPreload,
+		entryPreload:  entryPreload,
+		sharedPreload: sharedPreload,
+		style:         style,
+		script:        script,
+	}
+}
+
+func resolveStyleEntry(manifest Manifest, entry string, prefix string, options *invokeOptions) chunkTags {
+	entryInfo := manifest[entry]
+	style := ""
+	fontPreload := ""
+	discovered := make(map[string]bool)
+
+	var walk func(imports []string)
+	walk = func(imports []string) {
+		for _, importPath := range imports {
+			importEntryInfo, ok := manifest[importPath]
+			if !ok || discovered[importPath] {
+				continue
+			}
+
+			discovered[importPath] = true
+			walk(importEntryInfo.Imports)
+
+			for _, cssPath := range importEntryInfo.CSS {
+				style += options.fileTag(TagStyle, importPath, prefix, cssPath)
+			}
+
+			if inArray(path.Ext(importEntryInfo.File), styleExtensions) {
+				style += options.fileTag(TagStyle, importPath, prefix, importEntryInfo.File)
+			}
+
+			fontPreload += options.fontTags(importPath, prefix, importEntryInfo.Assets)
+		}
 	}
 
+	walk(entryInfo.Imports)
+	for _, cssPath := range entryInfo.CSS {
+		style += options.fileTag(TagStyle, entry, prefix, cssPath)
+	}
+	style += options.fileTag(TagStyle, entry, prefix, entryInfo.File)
+	fontPreload += options.fontTags(entry, prefix, entryInfo.Assets)
+
+	return chunkTags{
+		fontPreload: fontPreload,
+		style:       style,
+	}
+}
+
+func (tags chunkTags) merge(other chunkTags) chunkTags {
+	return chunkTags{
+		fontPreload:   tags.fontPreload + other.fontPreload,
+		entryPreload:  tags.entryPreload + other.entryPreload,
+		sharedPreload: tags.sharedPreload + other.sharedPreload,
+		style:         tags.style + other.style,
+		script:        tags.script + other.script,
+	}
+}
+
+func (tags chunkTags) htmlTags() HTMLTags {
 	return HTMLTags{
-		Preload: preload,
-		CSS:     style,
-		JS:      script,
+		Preload: tags.fontPreload + tags.entryPreload + tags.sharedPreload,
+		CSS:     tags.style,
+		JS:      tags.script,
 	}
 }
 
+func (tags chunkTags) render() string {
+	return tags.style + tags.fontPreload + tags.entryPreload + tags.sharedPreload + tags.script
+}
+
 func inArray(needle string, haystack []string) bool {
 	for _, item := range haystack {
 		if item == needle {
@@ -232,24 +563,53 @@ func inArray(needle string, haystack []string) bool {
 	return false
 }
 
-func createReactRefreshTag(origin string) string {
-	return fmt.Sprintf(`<script type="module">
-    import RefreshRuntime from '%s/@react-refresh';
+func createReactRefreshTag(origin string, nonce string) string {
+	return fmt.Sprintf(`<script type="module"%s>%s</script>`, nonceAttribute(nonce), reactRefreshSource(origin))
+}
+
+func reactRefreshSource(origin string) string {
+	return fmt.Sprintf(`
+    import RefreshRuntime from '%s';
     RefreshRuntime.injectIntoGlobalHook(window);
     window.$RefreshReg$ = () => {};
     window.$RefreshSig$ = () => (type) => type;
     window.__vite_plugin_react_preamble_installed__ = true;
-	</script>`, origin)
+	`, joinOrigin(origin, "@react-refresh"))
 }
 
-func createPreloadTag(path string) string {
-	return fmt.Sprintf(`<link rel="modulepreload" href="%s" />`, path)
+func joinOrigin(origin string, elem string) string {
+	joined, err := url.JoinPath(origin, elem)
+	if err != nil || origin == "" {
+		return strings.TrimSuffix(origin, "/") + "/" + elem
+	}
+
+	return joined
+}
+
+func createPreloadTag(path string, attributes string) string {
+	return fmt.Sprintf(`<link rel="modulepreload" href="%s"%s />`, path, attributes)
 }
 
-func createStyleTag(path string) string {
-	return fmt.Sprintf(`<link rel="stylesheet" href="%s" />`, path)
+func createFontPreloadTag(path string, attributes string) string {
+	return fmt.Sprintf(`<link rel="preload" href="%s" as="font"%s />`, path, attributes)
 }
 
-func createScriptTag(path string) string {
-	return fmt.Sprintf(`<script type="module" src="%s"></script>`, path)
+func createStyleTag(path string, attributes string) string {
+	return fmt.Sprintf(`<link rel="stylesheet" href="%s"%s />`, path, attributes)
+}
+
+func createScriptTag(path string, attributes string) string {
+	return fmt.Sprintf(`<script type="module" src="%s"%s></script>`, path, attributes)
+}
+
+func createClassicScriptTag(path string, attributes string) string {
+	return fmt.Sprintf(`<script src="%s" defer%s></script>`, path, attributes)
+}
+
+func nonceAttribute(nonce string) string {
+	if nonce == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(` nonce="%s"`, nonce)
 }
@@ -0,0 +1,3 @@
+import { greet } from "../../shared/greet.js";
+
+document.body.textContent = greet("vite");
@@ -0,0 +1,9 @@
+import { defineConfig } from "vite";
+
+export default defineConfig({
+  server: {
+    fs: {
+      allow: [".."],
+    },
+  },
+});
@@ -0,0 +1,124 @@
+package vitetest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	goviteparser "github.com/mrrizkin/go-vite-parser"
+)
+
+const startupTimeout = 30 * time.Second
+
+var tagURL = regexp.MustCompile(`(?:src|href)="([^"]+)"`)
+
+func StartDevServer(tb testing.TB, root string, args ...string) goviteparser.Config {
+	tb.Helper()
+
+	executable, ok := findVite(root)
+	if !ok {
+		tb.Skip("vite executable not found; run npm install in " + root)
+	}
+
+	port, err := freePort()
+	if err != nil {
+		tb.Fatalf("reserve dev server port: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	arguments := append([]string{"--host", "127.0.0.1", "--port", strconv.Itoa(port), "--strictPort"}, args...)
+	cmd := exec.CommandContext(ctx, executable, arguments...)
+	cmd.Dir = root
+	if err := cmd.Start(); err != nil {
+		cancel()
+		tb.Fatalf("start vite dev server: %v", err)
+	}
+
+	tb.Cleanup(func() {
+		cancel()
+		cmd.Wait()
+	})
+
+	origin := fmt.Sprintf("http://127.0.0.1:%d", port)
+	if err := waitForServer(origin + "/@vite/client"); err != nil {
+		tb.Fatalf("wait for vite dev server: %v", err)
+	}
+
+	hotFilePath := filepath.Join(tb.TempDir(), "hot")
+	if err := os.WriteFile(hotFilePath, []byte(origin), 0o644); err != nil {
+		tb.Fatalf("write hot file: %v", err)
+	}
+
+	return goviteparser.Config{
+		Mode:        goviteparser.ModeDevelopment,
+		HotFilePath: hotFilePath,
+		Root:        root,
+	}
+}
+
+func AssertTagsResolve(tb testing.TB, html string) {
+	tb.Helper()
+
+	matches := tagURL.FindAllStringSubmatch(html, -1)
+	if len(matches) == 0 {
+		tb.Errorf("no asset urls found in %q", html)
+	}
+
+	for _, match := range matches {
+		response, err := http.Get(match[1])
+		if err != nil {
+			tb.Errorf("fetch %s: %v", match[1], err)
+			continue
+		}
+
+		response.Body.Close()
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			tb.Errorf("fetch %s: unexpected status %s", match[1], response.Status)
+		}
+	}
+}
+
+func findVite(root string) (string, bool) {
+	local := filepath.Join(root, "node_modules", ".bin", "vite")
+	if _, err := os.Stat(local); err == nil {
+		return local, true
+	}
+
+	executable, err := exec.LookPath("vite")
+	return executable, err == nil
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+func waitForServer(url string) error {
+	deadline := time.Now().Add(startupTimeout)
+	for time.Now().Before(deadline) {
+		response, err := http.Get(url)
+		if err == nil {
+			response.Body.Close()
+			if response.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return fmt.Errorf("%s did not respond within %s", url, startupTimeout)
+}
@@ -0,0 +1,41 @@
+package vitetest
+
+import (
+	"context"
+	"testing"
+
+	goviteparser "github.com/mrrizkin/go-vite-parser"
+)
+
+func TestDevServerTagsResolve(t *testing.T) {
+	config := StartDevServer(t, "testdata/project")
+	config.DirectDevCSS = true
+
+	vite, err := goviteparser.Load(config)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	tags, err := vite.InvokeCtx(context.Background(), []string{"src/main.js", "src/style.css"})
+	if err != nil {
+		t.Fatalf("invoke: %v", err)
+	}
+
+	AssertTagsResolve(t, vite.ClientTag+tags)
+}
+
+func TestDevServerFSURLResolves(t *testing.T) {
+	config := StartDevServer(t, "testdata/project")
+
+	vite, err := goviteparser.Load(config)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	shared, err := vite.DevURL("../shared/greet.js")
+	if err != nil {
+		t.Fatalf("dev url: %v", err)
+	}
+
+	AssertTagsResolve(t, `<script type="module" src="`+shared+`"></script>`)
+}
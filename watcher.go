@@ -0,0 +1,221 @@
+package goviteparser
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	Watcher struct {
+		config   Config
+		interval time.Duration
+
+		snapshot         atomic.Pointer[watchSnapshot]
+		mu               sync.Mutex
+		onManifestChange []func(vite *ViteManifestInfo)
+		onHotModeChange  []func(vite *ViteManifestInfo)
+		subscribers      map[chan string]struct{}
+		failed           *watchFingerprint
+	}
+
+	watchSnapshot struct {
+		vite        *ViteManifestInfo
+		fingerprint watchFingerprint
+	}
+
+	watchFingerprint struct {
+		hot       fileFingerprint
+		manifests string
+	}
+
+	fileFingerprint struct {
+		exists  bool
+		size    int64
+		modTime int64
+	}
+)
+
+func NewWatcher(config Config, interval time.Duration) (*Watcher, error) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	watcher := &Watcher{
+		config:   config,
+		interval: interval,
+	}
+
+	fingerprint := watcher.currentFingerprint()
+	vite, err := Load(config)
+	watcher.snapshot.Store(&watchSnapshot{vite: &vite, fingerprint: fingerprint})
+
+	return watcher, err
+}
+
+func (watcher *Watcher) Vite() *ViteManifestInfo {
+	return watcher.snapshot.Load().vite
+}
+
+func (watcher *Watcher) OnManifestChange(callback func(vite *ViteManifestInfo)) {
+	watcher.mu.Lock()
+	defer watcher.mu.Unlock()
+
+	watcher.onManifestChange = append(watcher.onManifestChange, callback)
+}
+
+func (watcher *Watcher) OnHotModeChange(callback func(vite *ViteManifestInfo)) {
+	watcher.mu.Lock()
+	defer watcher.mu.Unlock()
+
+	watcher.onHotModeChange = append(watcher.onHotModeChange, callback)
+}
+
+func (watcher *Watcher) Watch(ctx context.Context) error {
+	ticker := time.NewTicker(watcher.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			watcher.poll(ctx)
+		}
+	}
+}
+
+func (watcher *Watcher) poll(ctx context.Context) {
+	fingerprint := watcher.currentFingerprint()
+	previous := watcher.snapshot.Load()
+	if fingerprint == previous.fingerprint && !watcher.config.hasManifestLoaders() {
+		return
+	}
+
+	vite, err := LoadCtx(ctx, watcher.config)
+	if err != nil {
+		watcher.mu.Lock()
+		repeated := watcher.failed != nil && *watcher.failed == fingerprint
+		watcher.failed = &fingerprint
+		watcher.mu.Unlock()
+
+		if !repeated {
+			watcher.config.warn(fmt.Errorf("reload manifest: %w", err))
+		}
+
+		return
+	}
+
+	manifestChanged := fingerprint.manifests != previous.fingerprint.manifests || vite.manifestsHash() != previous.vite.manifestsHash()
+	if fingerprint == previous.fingerprint && !manifestChanged && previous.vite.IsDev() == vite.IsDev() {
+		return
+	}
+
+	if !watcher.snapshot.CompareAndSwap(previous, &watchSnapshot{vite: &vite, fingerprint: fingerprint}) {
+		return
+	}
+
+	watcher.mu.Lock()
+	watcher.failed = nil
+	onManifestChange := watcher.onManifestChange
+	onHotModeChange := watcher.onHotModeChange
+	subscribers := make([]chan string, 0, len(watcher.subscribers))
+	for subscriber := range watcher.subscribers {
+		subscribers = append(subscribers, subscriber)
+	}
+	watcher.mu.Unlock()
+
+	if previous.vite.IsDev() != vite.IsDev() {
+		for _, callback := range onHotModeChange {
+			callback(&vite)
+		}
+	}
+
+	if manifestChanged {
+		for _, callback := range onManifestChange {
+			callback(&vite)
+		}
+	}
+
+	if hash := vite.ManifestHash(); hash != "" && hash != previous.vite.ManifestHash() {
+		for _, subscriber := range subscribers {
+			select {
+			case <-subscriber:
+			default:
+			}
+
+			subscriber <- hash
+		}
+	}
+}
+
+func (watcher *Watcher) currentFingerprint() watchFingerprint {
+	config := watcher.config
+	manifests := []string{}
+	if config.Loader == nil {
+		manifests = append(manifests, fmt.Sprint(statFingerprint(config.fileSystem(), filepath.Clean(config.ManifestPath))))
+	}
+
+	for _, name := range config.environmentNames() {
+		environment := config.Environments[name]
+		if environment.Loader == nil {
+			manifests = append(manifests, name+fmt.Sprint(statFingerprint(config.fileSystem(), filepath.Clean(environment.ManifestPath))))
+		}
+	}
+
+	return watchFingerprint{
+		hot:       statFingerprint(config.fileSystem(), filepath.Clean(config.HotFilePath)),
+		manifests: strings.Join(manifests, "\x00"),
+	}
+}
+
+func (config Config) hasManifestLoaders() bool {
+	if config.Loader != nil {
+		return true
+	}
+
+	for _, environment := range config.Environments {
+		if environment.Loader != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (config Config) environmentNames() []string {
+	names := make([]string, 0, len(config.Environments))
+	for name := range config.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (vite *ViteManifestInfo) manifestsHash() string {
+	hashes := []string{vite.manifestHash}
+	for _, name := range vite.config.environmentNames() {
+		hashes = append(hashes, name+"="+vite.environmentHashes[name])
+	}
+
+	return strings.Join(hashes, ",")
+}
+
+func statFingerprint(fileSystem FileSystem, filePath string) fileFingerprint {
+	info, err := fileSystem.Stat(filePath)
+	if err != nil || info.IsDir() {
+		return fileFingerprint{}
+	}
+
+	return fileFingerprint{
+		exists:  true,
+		size:    info.Size(),
+		modTime: info.ModTime().UnixNano(),
+	}
+}